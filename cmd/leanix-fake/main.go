@@ -0,0 +1,59 @@
+// Command leanix-fake serves the in-memory emulation of the LeanIX API, so the
+// provider can be used locally without a LeanIX workspace:
+//
+//	leanix-fake -addr localhost:8080 -fixture fixture.json
+//
+//	LEANIX_URL=http://localhost:8080 LEANIX_AUTH_HEADER="Basic $(printf apitoken:any | base64)" terraform apply
+//
+// All state is lost when the server stops.
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/codecentric/terraform-provider-leanix/leanix/fake"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "address to listen on")
+	fixturePath := flag.String("fixture", "", "JSON file with objects to seed the fake with")
+	apiToken := flag.String("api-token", "", "only accept this API token (default: accept any)")
+	workspaceId := flag.String("workspace-id", "", "ID of the emulated workspace (default: random)")
+	flag.Parse()
+
+	server := fake.NewServer()
+	server.ApiToken = *apiToken
+	if *workspaceId != "" {
+		server.WorkspaceId = *workspaceId
+	}
+
+	if *fixturePath != "" {
+		file, err := os.Open(*fixturePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fixture, err := fake.ReadFixture(file)
+		file.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := server.Load(fixture); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	log.Printf("Serving fake LeanIX API on http://%s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, logRequests(server)))
+}
+
+func logRequests(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		handler.ServeHTTP(w, r)
+		log.Printf("%s %s (%s)", r.Method, r.URL.Path, time.Since(start))
+	})
+}
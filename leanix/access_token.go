@@ -0,0 +1,55 @@
+package leanix
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// The claims of the JWT access token issued by MTM. They describe the
+// workspace the API token belongs to and the principal using it.
+type AccessTokenClaims struct {
+	InstanceUrl string         `json:"instanceUrl"`
+	Region      string         `json:"region"`
+	Expiry      int64          `json:"exp"`
+	Principal   TokenPrincipal `json:"principal"`
+}
+
+type TokenPrincipal struct {
+	Id         string          `json:"id"`
+	Username   string          `json:"username"`
+	Role       string          `json:"role"`
+	Account    TokenAccount    `json:"account"`
+	Permission TokenPermission `json:"permission"`
+}
+
+type TokenAccount struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type TokenPermission struct {
+	WorkspaceId   string `json:"workspaceId"`
+	WorkspaceName string `json:"workspaceName"`
+	Role          string `json:"role"`
+}
+
+// Decode the claims of a JWT access token. The signature is not verified,
+// as the token is only read to learn about the workspace, never trusted.
+func decodeAccessToken(accessToken string) (*AccessTokenClaims, error) {
+	parts := strings.Split(accessToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("The access token is no JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, errors.New("The access token is no JWT: " + err.Error())
+	}
+
+	claims := &AccessTokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, errors.New("The access token is no JWT: " + err.Error())
+	}
+	return claims, nil
+}
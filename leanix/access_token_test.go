@@ -0,0 +1,47 @@
+package leanix
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+)
+
+// Build an unsigned JWT with the given claims, like the ones MTM issues.
+func newTestAccessToken(t *testing.T, claims AccessTokenClaims) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	return header + "." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+func TestDecodeAccessToken(t *testing.T) {
+	claims := AccessTokenClaims{
+		InstanceUrl: "https://eu-svc.leanix.net",
+		Region:      "westeurope",
+		Expiry:      1790000000,
+		Principal: TokenPrincipal{
+			Id:       "1d9a3f5c-7a3e-4c8e-9f1b-2a6d5e4c3b2a",
+			Username: "apitoken-terraform",
+			Role:     "ACCOUNTUSER",
+			Permission: TokenPermission{
+				WorkspaceId:   "8751abbf-8093-410d-a090-10c7735952cf",
+				WorkspaceName: "sandbox",
+				Role:          "ADMIN",
+			},
+		},
+	}
+
+	decoded, err := decodeAccessToken(newTestAccessToken(t, claims))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, *decoded, claims)
+
+	for _, accessToken := range []string{"this_is_my_awesome_oauth_token", "a.b", "a.!!!.c", "a." + base64.RawURLEncoding.EncodeToString([]byte("[]")) + ".c"} {
+		if _, err := decodeAccessToken(accessToken); err == nil {
+			t.Fatalf("Expected %q to be rejected", accessToken)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package leanix
+
+import (
+	"encoding/json"
+)
+
+// An automation runs its actions whenever its trigger fires and all its
+// conditions hold. Trigger, conditions and actions are passed through as JSON,
+// as their structure depends on the respective type.
+type Automation struct {
+	Id          *string         `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Enabled     bool            `json:"enabled"`
+	Trigger     json.RawMessage `json:"trigger"`
+	Conditions  json.RawMessage `json:"conditions"`
+	Actions     json.RawMessage `json:"actions"`
+}
+
+type AutomationResponse struct {
+	Status     string      `json:"status"`
+	Automation *Automation `json:"data"`
+}
@@ -0,0 +1,23 @@
+package leanix
+
+import (
+	"encoding/json"
+)
+
+type Bookmark struct {
+	Id          *string         `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Type        string          `json:"type"`
+	Sharing     string          `json:"sharing"`
+	State       json.RawMessage `json:"state"`
+}
+
+type BookmarkResponse struct {
+	Status   string    `json:"status"`
+	Bookmark *Bookmark `json:"data"`
+}
+
+var bookmarkTypes = []string{"INVENTORY", "MATRIX", "VISUALIZER", "DASHBOARD", "REPORTING"}
+
+var bookmarkSharings = []string{"PERSONAL", "SHARED", "SYSTEM"}
@@ -0,0 +1,190 @@
+// Package cassette records HTTP interactions with LeanIX into cassette files
+// and replays them, so client tests can be based on real responses without
+// network access.
+//
+// Secrets are scrubbed before an interaction is stored: request headers
+// besides Content-Type are dropped and the values of secret fields like
+// access tokens are redacted.
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/codecentric/terraform-provider-leanix/leanix/redact"
+)
+
+type Mode int
+
+const (
+	// Replay the interactions of an existing cassette. Requests which don't
+	// match the next recorded interaction fail.
+	ModeReplay Mode = iota
+	// Send requests to the real server and store the interactions in the
+	// cassette when the recorder is stopped.
+	ModeRecord
+)
+
+type Cassette struct {
+	// Where the interactions come from, e.g. to mark cassettes which weren't
+	// recorded against LeanIX but against the fake server.
+	Source       string        `json:"source,omitempty"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Only the path including the query is stored, so a cassette recorded against
+// one LeanIX instance can be replayed against any URL.
+type Request struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+type Response struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording or replaying a cassette.
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	cassette  Cassette
+	next      int
+	sync.Mutex
+}
+
+// Create a recorder for the cassette file at path.
+// In replay mode the cassette must exist. In record mode requests are sent
+// through transport, or the default transport if it is nil.
+func New(path string, mode Mode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	recorder := &Recorder{path: path, mode: mode, transport: transport}
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("Invalid cassette %s: %s", path, err)
+		}
+	}
+	return recorder, nil
+}
+
+func (recorder *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	request := Request{
+		Method:      req.Method,
+		Path:        req.URL.RequestURI(),
+		ContentType: req.Header.Get("Content-Type"),
+		Body:        redact.Body(req.Header.Get("Content-Type"), body),
+	}
+
+	recorder.Lock()
+	defer recorder.Unlock()
+	if recorder.mode == ModeRecord {
+		return recorder.record(req, request)
+	}
+	return recorder.replay(req, request)
+}
+
+func (recorder *Recorder) record(req *http.Request, request Request) (*http.Response, error) {
+	resp, err := recorder.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, Interaction{
+		Request: request,
+		Response: Response{
+			Status:      resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        redact.Body(resp.Header.Get("Content-Type"), respBody),
+		},
+	})
+	return resp, nil
+}
+
+// Interactions are replayed in the order they were recorded, as LeanIX
+// returns different responses for the same request over time.
+func (recorder *Recorder) replay(req *http.Request, request Request) (*http.Response, error) {
+	if recorder.next >= len(recorder.cassette.Interactions) {
+		return nil, fmt.Errorf("Cassette %s has no more interactions for %s %s", recorder.path, request.Method, request.Path)
+	}
+	interaction := recorder.cassette.Interactions[recorder.next]
+	if interaction.Request != request {
+		return nil, fmt.Errorf("Request %d doesn't match cassette %s, expected %+v but got %+v", recorder.next, recorder.path, interaction.Request, request)
+	}
+	recorder.next++
+
+	header := http.Header{}
+	if interaction.Response.ContentType != "" {
+		header.Set("Content-Type", interaction.Response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+		StatusCode:    interaction.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(interaction.Response.Body))),
+		ContentLength: int64(len(interaction.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Stop the recorder. In record mode the cassette is written to its file,
+// in replay mode it is an error if not all interactions were replayed.
+func (recorder *Recorder) Stop() error {
+	recorder.Lock()
+	defer recorder.Unlock()
+
+	if recorder.mode == ModeReplay {
+		if recorder.next != len(recorder.cassette.Interactions) {
+			return fmt.Errorf("Only %d of %d interactions of cassette %s were replayed", recorder.next, len(recorder.cassette.Interactions), recorder.path)
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(recorder.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(recorder.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(recorder.path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,109 @@
+package cassette
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/services/mtm/v1/oauth2/token" {
+			w.Write([]byte(`{"access_token": "secret-token", "token_type": "Bearer"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"status": "OK", "data": {"id": "1", "authorizationHeader": "Basic secret"}}`))
+	}))
+	defer server.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := New(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: recorder}
+	tokenBody := post(t, client, server.URL+"/services/mtm/v1/oauth2/token", "application/x-www-form-urlencoded", "grant_type=client_credentials", http.StatusOK)
+	if !strings.Contains(tokenBody, "secret-token") {
+		t.Fatalf("Expected the real response while recording, got %s", tokenBody)
+	}
+	post(t, client, server.URL+"/services/webhooks/v1/subscriptions?x=1", "application/json", `{"identifier": "a", "authorizationHeader": "Basic secret"}`, http.StatusCreated)
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("Expected secrets to be scrubbed from the cassette: %s", data)
+	}
+
+	server.Close()
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = &http.Client{Transport: replayer}
+	tokenBody = post(t, client, "https://another.leanix.invalid/services/mtm/v1/oauth2/token", "application/x-www-form-urlencoded", "grant_type=client_credentials", http.StatusOK)
+	assertEqual(t, tokenBody, `{"access_token":"REDACTED","token_type":"Bearer"}`)
+	if err := replayer.Stop(); err == nil {
+		t.Fatal("Expected an error when not all interactions were replayed")
+	}
+	// the authorization header is scrubbed from the recorded request as well, so any value matches
+	post(t, client, "https://another.leanix.invalid/services/webhooks/v1/subscriptions?x=1", "application/json", `{"identifier": "a", "authorizationHeader": "Basic other"}`, http.StatusCreated)
+	if err := replayer.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Get("https://another.leanix.invalid/services/webhooks/v1/subscriptions")
+	if err == nil {
+		t.Fatal("Expected an error for a request beyond the cassette")
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, _ := New(path, ModeRecord, nil)
+	recorder.cassette.Interactions = []Interaction{{
+		Request:  Request{Method: "GET", Path: "/services/webhooks/v1/subscriptions/1"},
+		Response: Response{Status: http.StatusOK},
+	}}
+	if err := recorder.Stop(); err != nil {
+		t.Fatal(err)
+	}
+
+	replayer, err := New(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: replayer}
+	if _, err := client.Get("https://leanix.invalid/services/webhooks/v1/subscriptions/2"); err == nil {
+		t.Fatal("Expected an error for a request not matching the cassette")
+	}
+}
+
+func post(t *testing.T, client *http.Client, url string, contentType string, body string, expectedStatus int) string {
+	resp, err := client.Post(url, contentType, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, resp.StatusCode, expectedStatus)
+	return string(respBody)
+}
+
+func assertEqual(t *testing.T, actual interface{}, expected interface{}) {
+	if actual != expected {
+		t.Fatalf("Expected %v to be equal to %v", expected, actual)
+	}
+}
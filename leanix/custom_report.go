@@ -0,0 +1,153 @@
+package leanix
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+type CustomReport struct {
+	Id       string `json:"id"`
+	ReportId string `json:"reportId"`
+	Version  string `json:"version"`
+	Title    string `json:"title"`
+	Enabled  bool   `json:"enabled"`
+}
+
+type CustomReportResponse struct {
+	Status string        `json:"status"`
+	Report *CustomReport `json:"data"`
+}
+
+// The manifest of a custom report, the lxreport.json file in the root of the bundle.
+type CustomReportManifest struct {
+	Id          string `json:"id"`
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Author      string `json:"author"`
+	Description string `json:"description"`
+}
+
+type CustomReportBundle struct {
+	Zip      []byte
+	Hash     string
+	Manifest CustomReportManifest
+}
+
+// Load a built custom report either from a zip file or from a directory.
+// Directories are zipped in a reproducible way, so the same content always
+// results in the same hash.
+func loadCustomReportBundle(source string) (*CustomReportBundle, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var zipBytes []byte
+	if info.IsDir() {
+		zipBytes, err = zipDirectory(source)
+	} else {
+		zipBytes, err = ioutil.ReadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := readCustomReportManifest(zipBytes)
+	if err != nil {
+		return nil, errors.New("Invalid custom report bundle '" + source + "': " + err.Error())
+	}
+
+	hash := sha256.Sum256(zipBytes)
+	return &CustomReportBundle{
+		Zip:      zipBytes,
+		Hash:     hex.EncodeToString(hash[:]),
+		Manifest: *manifest,
+	}, nil
+}
+
+func readCustomReportManifest(zipBytes []byte) (*CustomReportManifest, error) {
+	reader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range reader.File {
+		if file.Name != "lxreport.json" {
+			continue
+		}
+		content, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer content.Close()
+
+		manifest := CustomReportManifest{}
+		err = json.NewDecoder(content).Decode(&manifest)
+		if err != nil {
+			return nil, errors.New("lxreport.json is not valid JSON: " + err.Error())
+		}
+		if manifest.Id == "" || manifest.Version == "" {
+			return nil, errors.New("lxreport.json must contain an id and a version")
+		}
+		return &manifest, nil
+	}
+	return nil, errors.New("lxreport.json not found in the root of the bundle")
+}
+
+// Zip all files of a directory with paths relative to it. Files are added in
+// sorted order without modification times to get the same bytes for the same content.
+func zipDirectory(directory string) ([]byte, error) {
+	var paths []string
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	buffer := &bytes.Buffer{}
+	writer := zip.NewWriter(buffer)
+	for _, path := range paths {
+		relativePath, err := filepath.Rel(directory, path)
+		if err != nil {
+			return nil, err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fileWriter, err := writer.CreateHeader(&zip.FileHeader{
+			Name:     filepath.ToSlash(relativePath),
+			Method:   zip.Deflate,
+			Modified: time.Unix(0, 0).UTC(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, err = fileWriter.Write(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
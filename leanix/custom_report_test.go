@@ -0,0 +1,87 @@
+package leanix
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestCustomReport(t *testing.T, files map[string]string) string {
+	directory, err := ioutil.TempDir("", "custom-report")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(directory, name)
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return directory
+}
+
+func TestLoadCustomReportBundle(t *testing.T) {
+	directory := writeTestCustomReport(t, map[string]string{
+		"lxreport.json":  `{"id": "net.example.report", "title": "Example", "version": "1.2.0"}`,
+		"index.html":     "<html></html>",
+		"assets/main.js": "console.log('report')",
+	})
+	defer os.RemoveAll(directory)
+
+	bundle, err := loadCustomReportBundle(directory)
+	if err != nil {
+		t.Fatalf("loadCustomReportBundle() returned an error: %s", err)
+	}
+	assertEqual(t, bundle.Manifest, CustomReportManifest{Id: "net.example.report", Title: "Example", Version: "1.2.0"})
+
+	// zipping the same content again must result in the same hash
+	again, err := loadCustomReportBundle(directory)
+	if err != nil {
+		t.Fatalf("loadCustomReportBundle() returned an error: %s", err)
+	}
+	assertEqual(t, again.Hash, bundle.Hash)
+
+	// zip files are used as they are
+	zipPath := filepath.Join(directory, "..", filepath.Base(directory)+".zip")
+	err = ioutil.WriteFile(zipPath, bundle.Zip, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(zipPath)
+	fromZip, err := loadCustomReportBundle(zipPath)
+	if err != nil {
+		t.Fatalf("loadCustomReportBundle() returned an error: %s", err)
+	}
+	assertEqual(t, fromZip.Hash, bundle.Hash)
+
+	err = ioutil.WriteFile(filepath.Join(directory, "index.html"), []byte("<html>changed</html>"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := loadCustomReportBundle(directory)
+	if err != nil {
+		t.Fatalf("loadCustomReportBundle() returned an error: %s", err)
+	}
+	if changed.Hash == bundle.Hash {
+		t.Fatal("Expected a different hash for changed content")
+	}
+}
+
+func TestLoadCustomReportBundleWithoutManifest(t *testing.T) {
+	directory := writeTestCustomReport(t, map[string]string{
+		"dist/lxreport.json": `{"id": "net.example.report", "version": "1.2.0"}`,
+	})
+	defer os.RemoveAll(directory)
+
+	_, err := loadCustomReportBundle(directory)
+	if err == nil || !strings.Contains(err.Error(), "lxreport.json not found") {
+		t.Fatalf("Expected an error for a missing manifest but got %v", err)
+	}
+}
@@ -0,0 +1,273 @@
+package leanix
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// The data model is handled as generic JSON document. This way we only touch
+// the parts managed by Terraform and keep everything else as it is.
+type DataModel map[string]interface{}
+
+type DataModelResponse struct {
+	Status    string    `json:"status"`
+	DataModel DataModel `json:"data"`
+}
+
+var dataModelFieldTypes = []string{
+	"STRING",
+	"INTEGER",
+	"DOUBLE",
+	"SINGLE_SELECT",
+	"MULTIPLE_SELECT",
+	"LOCATION",
+	"EXTERNAL_ID",
+	"PROJECT_STATUS",
+	"LIFECYCLE",
+}
+
+// Return the definition of a field of a fact sheet type or nil if either does not exist.
+func (dataModel DataModel) Field(factSheetType string, fieldName string) map[string]interface{} {
+	fields := dataModel.fields(factSheetType)
+	if fields == nil {
+		return nil
+	}
+	field, _ := fields[fieldName].(map[string]interface{})
+	return field
+}
+
+// Set the definition of a field of a fact sheet type. A nil field removes the field.
+// Fails if the fact sheet type does not exist.
+func (dataModel DataModel) SetField(factSheetType string, fieldName string, field map[string]interface{}) error {
+	factSheets, _ := dataModel["factSheets"].(map[string]interface{})
+	factSheet, _ := factSheets[factSheetType].(map[string]interface{})
+	if factSheet == nil {
+		return errors.New("Fact sheet type '" + factSheetType + "' does not exist in the data model.")
+	}
+	fields, _ := factSheet["fields"].(map[string]interface{})
+	if fields == nil {
+		fields = map[string]interface{}{}
+		factSheet["fields"] = fields
+	}
+	if field == nil {
+		delete(fields, fieldName)
+	} else {
+		fields[fieldName] = field
+	}
+	return nil
+}
+
+// Add an empty fact sheet type unless it exists already.
+// Used for documents like translations which only contain customized types.
+func (dataModel DataModel) ensureFactSheet(factSheetType string) {
+	factSheets, _ := dataModel["factSheets"].(map[string]interface{})
+	if factSheets == nil {
+		factSheets = map[string]interface{}{}
+		dataModel["factSheets"] = factSheets
+	}
+	if _, ok := factSheets[factSheetType].(map[string]interface{}); !ok {
+		factSheets[factSheetType] = map[string]interface{}{}
+	}
+}
+
+func (dataModel DataModel) fields(factSheetType string) map[string]interface{} {
+	factSheets, _ := dataModel["factSheets"].(map[string]interface{})
+	factSheet, _ := factSheets[factSheetType].(map[string]interface{})
+	fields, _ := factSheet["fields"].(map[string]interface{})
+	return fields
+}
+
+// Extract the allowed values of a select field definition.
+func dataModelFieldValues(field map[string]interface{}) []string {
+	var values []string
+	rawValues, _ := field["values"].([]interface{})
+	for _, rawValue := range rawValues {
+		if value, ok := rawValue.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Return the values contained in oldValues but not in newValues in sorted order.
+// Removing a value deletes it from all fact sheets using it.
+func removedDataModelFieldValues(oldValues []string, newValues []string) []string {
+	remaining := map[string]bool{}
+	for _, value := range newValues {
+		remaining[value] = true
+	}
+	var removed []string
+	for _, value := range oldValues {
+		if !remaining[value] {
+			removed = append(removed, value)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// Validate the structure of a complete data model document.
+// All problems found are returned so they can be fixed at once.
+func validateDataModel(dataModel DataModel) []error {
+	var errs []error
+	factSheets, ok := dataModel["factSheets"].(map[string]interface{})
+	if !ok || len(factSheets) == 0 {
+		return []error{errors.New("the data model must contain at least one fact sheet type in 'factSheets'")}
+	}
+
+	validFieldTypes := map[string]bool{}
+	for _, fieldType := range dataModelFieldTypes {
+		validFieldTypes[fieldType] = true
+	}
+	for _, factSheetType := range sortedKeys(factSheets) {
+		factSheet, ok := factSheets[factSheetType].(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Errorf("fact sheet type '%s' must be an object", factSheetType))
+			continue
+		}
+		rawFields, hasFields := factSheet["fields"]
+		fields, ok := rawFields.(map[string]interface{})
+		if hasFields && !ok {
+			errs = append(errs, fmt.Errorf("fields of fact sheet type '%s' must be an object", factSheetType))
+			continue
+		}
+		for _, fieldName := range sortedKeys(fields) {
+			field, ok := fields[fieldName].(map[string]interface{})
+			if !ok {
+				errs = append(errs, fmt.Errorf("field '%s.%s' must be an object", factSheetType, fieldName))
+				continue
+			}
+			fieldType, _ := field["type"].(string)
+			if !validFieldTypes[fieldType] {
+				errs = append(errs, fmt.Errorf("field '%s.%s' has the invalid type '%v'", factSheetType, fieldName, field["type"]))
+				continue
+			}
+			if fieldType == "SINGLE_SELECT" || fieldType == "MULTIPLE_SELECT" {
+				values := dataModelFieldValues(field)
+				rawValues, _ := field["values"].([]interface{})
+				if len(values) == 0 || len(values) != len(rawValues) {
+					errs = append(errs, fmt.Errorf("field '%s.%s' of type %s must have a list of string values", factSheetType, fieldName, fieldType))
+				} else if duplicates := duplicateStrings(values); len(duplicates) > 0 {
+					errs = append(errs, fmt.Errorf("field '%s.%s' has duplicate values: %v", factSheetType, fieldName, duplicates))
+				}
+			}
+		}
+	}
+
+	rawRelations, hasRelations := dataModel["relations"]
+	relations, ok := rawRelations.(map[string]interface{})
+	if hasRelations && !ok {
+		return append(errs, errors.New("'relations' must be an object"))
+	}
+	for _, relationName := range sortedKeys(relations) {
+		relation, ok := relations[relationName].(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Errorf("relation '%s' must be an object", relationName))
+			continue
+		}
+		for _, end := range []string{"from", "to"} {
+			endpoint, _ := relation[end].(map[string]interface{})
+			factSheetType, _ := endpoint["factSheetType"].(string)
+			if _, ok := factSheets[factSheetType]; !ok {
+				errs = append(errs, fmt.Errorf("relation '%s' references the unknown fact sheet type '%s' in '%s'", relationName, factSheetType, end))
+			}
+		}
+	}
+	return errs
+}
+
+// A single semantic difference between two data models.
+// Destructive changes delete data from fact sheets.
+type DataModelChange struct {
+	Description string
+	Destructive bool
+}
+
+// Compare two data models and describe the added, removed and changed fact sheet
+// types, fields and relations in a stable order.
+func diffDataModels(oldDataModel DataModel, newDataModel DataModel) []DataModelChange {
+	var changes []DataModelChange
+	oldFactSheets, _ := oldDataModel["factSheets"].(map[string]interface{})
+	newFactSheets, _ := newDataModel["factSheets"].(map[string]interface{})
+	for _, factSheetType := range sortedKeys(oldFactSheets, newFactSheets) {
+		oldFactSheet, oldExists := oldFactSheets[factSheetType]
+		newFactSheet, newExists := newFactSheets[factSheetType]
+		if !oldExists {
+			changes = append(changes, DataModelChange{Description: "+ fact sheet type " + factSheetType})
+			continue
+		}
+		if !newExists {
+			changes = append(changes, DataModelChange{Description: "- fact sheet type " + factSheetType, Destructive: true})
+			continue
+		}
+
+		oldFields := DataModel{"factSheets": map[string]interface{}{factSheetType: oldFactSheet}}.fields(factSheetType)
+		newFields := DataModel{"factSheets": map[string]interface{}{factSheetType: newFactSheet}}.fields(factSheetType)
+		for _, fieldName := range sortedKeys(oldFields, newFields) {
+			oldField, oldExists := oldFields[fieldName].(map[string]interface{})
+			newField, newExists := newFields[fieldName].(map[string]interface{})
+			qualifiedName := factSheetType + "." + fieldName
+			switch {
+			case !oldExists:
+				changes = append(changes, DataModelChange{Description: "+ field " + qualifiedName})
+			case !newExists:
+				changes = append(changes, DataModelChange{Description: "- field " + qualifiedName, Destructive: true})
+			case oldField["type"] != newField["type"]:
+				changes = append(changes, DataModelChange{Description: fmt.Sprintf("~ field %s: type %v -> %v", qualifiedName, oldField["type"], newField["type"]), Destructive: true})
+			case !reflect.DeepEqual(oldField, newField):
+				removed := removedDataModelFieldValues(dataModelFieldValues(oldField), dataModelFieldValues(newField))
+				if len(removed) > 0 {
+					changes = append(changes, DataModelChange{Description: fmt.Sprintf("~ field %s: removed values %v", qualifiedName, removed), Destructive: true})
+				} else {
+					changes = append(changes, DataModelChange{Description: "~ field " + qualifiedName})
+				}
+			}
+		}
+	}
+
+	oldRelations, _ := oldDataModel["relations"].(map[string]interface{})
+	newRelations, _ := newDataModel["relations"].(map[string]interface{})
+	for _, relationName := range sortedKeys(oldRelations, newRelations) {
+		oldRelation, oldExists := oldRelations[relationName]
+		newRelation, newExists := newRelations[relationName]
+		switch {
+		case !oldExists:
+			changes = append(changes, DataModelChange{Description: "+ relation " + relationName})
+		case !newExists:
+			changes = append(changes, DataModelChange{Description: "- relation " + relationName, Destructive: true})
+		case !reflect.DeepEqual(oldRelation, newRelation):
+			changes = append(changes, DataModelChange{Description: "~ relation " + relationName})
+		}
+	}
+	return changes
+}
+
+// Return the union of the keys of all given objects in sorted order.
+func sortedKeys(objects ...map[string]interface{}) []string {
+	unique := map[string]bool{}
+	for _, object := range objects {
+		for key := range object {
+			unique[key] = true
+		}
+	}
+	keys := make([]string, 0, len(unique))
+	for key := range unique {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func duplicateStrings(values []string) []string {
+	seen := map[string]bool{}
+	var duplicates []string
+	for _, value := range values {
+		if seen[value] {
+			duplicates = append(duplicates, value)
+		}
+		seen[value] = true
+	}
+	return duplicates
+}
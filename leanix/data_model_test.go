@@ -0,0 +1,160 @@
+package leanix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const testDataModelJson = `{
+  "factSheets": {
+    "Application": {
+      "fields": {
+        "alias": {"type": "STRING", "qualitySeal": false},
+        "businessCriticality": {"type": "SINGLE_SELECT", "values": ["missionCritical", "businessCritical"]}
+      },
+      "relations": {"relApplicationToITComponent": {}}
+    }
+  }
+}`
+
+func testDataModel(t *testing.T) DataModel {
+	dataModel := DataModel{}
+	err := json.Unmarshal([]byte(testDataModelJson), &dataModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dataModel
+}
+
+func TestDataModelField(t *testing.T) {
+	dataModel := testDataModel(t)
+
+	assertEqual(t, dataModel.Field("Application", "alias"), map[string]interface{}{"type": "STRING", "qualitySeal": false})
+	assertEqual(t, dataModelFieldValues(dataModel.Field("Application", "businessCriticality")), []string{"missionCritical", "businessCritical"})
+	if dataModel.Field("Application", "unknown") != nil {
+		t.Fatal("Expected no field 'unknown'")
+	}
+	if dataModel.Field("Unknown", "alias") != nil {
+		t.Fatal("Expected no fact sheet type 'Unknown'")
+	}
+}
+
+func TestDataModelSetField(t *testing.T) {
+	dataModel := testDataModel(t)
+
+	err := dataModel.SetField("Application", "costCenter", map[string]interface{}{"type": "STRING"})
+	if err != nil {
+		t.Fatalf("DataModel.SetField() returned an error: %s", err)
+	}
+	err = dataModel.SetField("Application", "alias", nil)
+	if err != nil {
+		t.Fatalf("DataModel.SetField() returned an error: %s", err)
+	}
+
+	expected := testDataModel(t)
+	fields := expected["factSheets"].(map[string]interface{})["Application"].(map[string]interface{})["fields"].(map[string]interface{})
+	delete(fields, "alias")
+	fields["costCenter"] = map[string]interface{}{"type": "STRING"}
+	assertEqual(t, dataModel, expected)
+
+	if err := dataModel.SetField("Unknown", "alias", map[string]interface{}{"type": "STRING"}); err == nil {
+		t.Fatal("DataModel.SetField() should fail for unknown fact sheet types")
+	}
+}
+
+func TestRemovedDataModelFieldValues(t *testing.T) {
+	removed := removedDataModelFieldValues([]string{"c", "a", "b"}, []string{"b", "d"})
+	assertEqual(t, removed, []string{"a", "c"})
+
+	if removed := removedDataModelFieldValues([]string{"a"}, []string{"a", "b"}); removed != nil {
+		t.Fatalf("Expected no removed values but got %v", removed)
+	}
+}
+
+func TestValidateDataModel(t *testing.T) {
+	valid := DataModel{}
+	err := json.Unmarshal([]byte(`{
+  "factSheets": {
+    "Application": {"fields": {"hostingModel": {"type": "SINGLE_SELECT", "values": ["cloud", "saas"]}}},
+    "ITComponent": {}
+  },
+  "relations": {"relApplicationToITComponent": {"from": {"factSheetType": "Application"}, "to": {"factSheetType": "ITComponent"}}}
+}`), &valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := validateDataModel(valid); len(errs) > 0 {
+		t.Fatalf("Expected a valid data model but got %v", errs)
+	}
+
+	invalid := DataModel{}
+	err = json.Unmarshal([]byte(`{
+  "factSheets": {
+    "Application": {"fields": {"hostingModel": {"type": "SINGLE_SELECT", "values": ["cloud", "cloud"]}, "alias": {"type": "TEXT"}}}
+  },
+  "relations": {"relApplicationToITComponent": {"from": {"factSheetType": "Application"}, "to": {"factSheetType": "ITComponent"}}}
+}`), &invalid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var messages []string
+	for _, err := range validateDataModel(invalid) {
+		messages = append(messages, err.Error())
+	}
+	assertEqual(t, messages, []string{
+		"field 'Application.alias' has the invalid type 'TEXT'",
+		"field 'Application.hostingModel' has duplicate values: [cloud]",
+		"relation 'relApplicationToITComponent' references the unknown fact sheet type 'ITComponent' in 'to'",
+	})
+
+	if errs := validateDataModel(DataModel{}); len(errs) != 1 {
+		t.Fatalf("Expected an error for a data model without fact sheet types but got %v", errs)
+	}
+}
+
+func TestDiffDataModels(t *testing.T) {
+	oldDataModel := DataModel{}
+	err := json.Unmarshal([]byte(`{
+  "factSheets": {
+    "Application": {"fields": {
+      "alias": {"type": "STRING"},
+      "hostingModel": {"type": "SINGLE_SELECT", "values": ["cloud", "onPremise"]},
+      "costCenter": {"type": "STRING"}
+    }},
+    "Project": {}
+  },
+  "relations": {"relApplicationToProject": {"from": {"factSheetType": "Application"}, "to": {"factSheetType": "Project"}}}
+}`), &oldDataModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newDataModel := DataModel{}
+	err = json.Unmarshal([]byte(`{
+  "factSheets": {
+    "Application": {"fields": {
+      "alias": {"type": "STRING", "mandatory": true},
+      "hostingModel": {"type": "SINGLE_SELECT", "values": ["cloud"]},
+      "costCenter": {"type": "INTEGER"},
+      "owner": {"type": "STRING"}
+    }},
+    "ITComponent": {}
+  }
+}`), &newDataModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, diffDataModels(oldDataModel, newDataModel), []DataModelChange{
+		{Description: "~ field Application.alias"},
+		{Description: "~ field Application.costCenter: type STRING -> INTEGER", Destructive: true},
+		{Description: "~ field Application.hostingModel: removed values [onPremise]", Destructive: true},
+		{Description: "+ field Application.owner"},
+		{Description: "+ fact sheet type ITComponent"},
+		{Description: "- fact sheet type Project", Destructive: true},
+		{Description: "- relation relApplicationToProject", Destructive: true},
+	})
+
+	if changes := diffDataModels(newDataModel, newDataModel); changes != nil {
+		t.Fatalf("Expected no changes but got %v", changes)
+	}
+}
@@ -0,0 +1,74 @@
+package leanix
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceLeanixCurrentWorkspace() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLeanixCurrentWorkspaceRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"workspace_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"instance_url": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"account_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"principal_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"principal_username": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"role": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role of the principal in the workspace, e.g. ADMIN.",
+			},
+		},
+	}
+}
+
+func dataSourceLeanixCurrentWorkspaceRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	claims, err := leanixClient.ReadAccessTokenClaims()
+	if err != nil {
+		return err
+	}
+
+	permission := claims.Principal.Permission
+	d.SetId(permission.WorkspaceId)
+	d.Set("workspace_id", permission.WorkspaceId)
+	d.Set("workspace_name", permission.WorkspaceName)
+	d.Set("instance_url", claims.InstanceUrl)
+	d.Set("region", claims.Region)
+	d.Set("account_id", claims.Principal.Account.Id)
+	d.Set("account_name", claims.Principal.Account.Name)
+	d.Set("principal_id", claims.Principal.Id)
+	d.Set("principal_username", claims.Principal.Username)
+	d.Set("role", permission.Role)
+
+	return nil
+}
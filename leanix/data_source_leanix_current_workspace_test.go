@@ -0,0 +1,79 @@
+package leanix
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceLeanixCurrentWorkspaceRead(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, _ := NewAuthRouteDefinition(t, apiToken)
+	accessToken := newTestAccessToken(t, AccessTokenClaims{
+		InstanceUrl: "https://eu-svc.leanix.net",
+		Principal: TokenPrincipal{
+			Id:       "1d9a3f5c-7a3e-4c8e-9f1b-2a6d5e4c3b2a",
+			Username: "apitoken-terraform",
+			Account:  TokenAccount{Id: "a3c1", Name: "ACME"},
+			Permission: TokenPermission{
+				WorkspaceId:   "8751abbf-8093-410d-a090-10c7735952cf",
+				WorkspaceName: "sandbox",
+				Role:          "ADMIN",
+			},
+		},
+	})
+	authRoute.ResponseBody = func(header http.Header, body []byte) []byte {
+		responseMarshal, err := json.Marshal(&AuthResponse{AccessToken: accessToken, TokenType: "Bearer", ExpiresIn: 3600})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return responseMarshal
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+		},
+	)
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+
+	d := schema.TestResourceDataRaw(t, dataSourceLeanixCurrentWorkspace().Schema, map[string]interface{}{})
+	if err := dataSourceLeanixCurrentWorkspaceRead(d, client); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, d.Id(), "8751abbf-8093-410d-a090-10c7735952cf")
+	assertEqual(t, d.Get("workspace_name"), "sandbox")
+	assertEqual(t, d.Get("instance_url"), "https://eu-svc.leanix.net")
+	assertEqual(t, d.Get("account_name"), "ACME")
+	assertEqual(t, d.Get("principal_username"), "apitoken-terraform")
+	assertEqual(t, d.Get("role"), "ADMIN")
+}
+
+func TestDataSourceLeanixCurrentWorkspaceReadOpaqueToken(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, _ := NewAuthRouteDefinition(t, apiToken)
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+		},
+	)
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+
+	d := schema.TestResourceDataRaw(t, dataSourceLeanixCurrentWorkspace().Schema, map[string]interface{}{})
+	if err := dataSourceLeanixCurrentWorkspaceRead(d, client); err == nil {
+		t.Fatal("Expected an error for an access token without claims")
+	}
+}
@@ -0,0 +1,164 @@
+package leanix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceLeanixLdif() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLeanixLdifRead,
+
+		Schema: map[string]*schema.Schema{
+			"connector_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"connector_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"connector_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"lx_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "1.0.0",
+			},
+			"lx_workspace": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"processing_direction": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"inbound", "outbound"}, false),
+			},
+			"processing_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"partial", "full"}, false),
+			},
+			"content": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"data": &schema.Schema{
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Flat string attributes of the content item.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"data_json": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Attributes of the content item as JSON object, e.g. for nested values or lists.",
+							ValidateFunc: validateJsonObjectString,
+						},
+					},
+				},
+			},
+			"json": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceLeanixLdifRead(d *schema.ResourceData, meta interface{}) error {
+	ldif, err := extractLdif(d)
+	if err != nil {
+		return err
+	}
+
+	if errs := validateLdif(*ldif); len(errs) > 0 {
+		var messages []string
+		for _, validationError := range errs {
+			messages = append(messages, validationError.Error())
+		}
+		return errors.New("The LDIF document is invalid:\n" + strings.Join(messages, "\n"))
+	}
+
+	document, err := json.Marshal(ldif)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hashLdif(string(document)))
+	d.Set("json", string(document))
+	return nil
+}
+
+func extractLdif(d *schema.ResourceData) (*Ldif, error) {
+	ldif := &Ldif{
+		ConnectorType:       d.Get("connector_type").(string),
+		ConnectorId:         d.Get("connector_id").(string),
+		ConnectorVersion:    d.Get("connector_version").(string),
+		LxVersion:           d.Get("lx_version").(string),
+		LxWorkspace:         d.Get("lx_workspace").(string),
+		Description:         d.Get("description").(string),
+		ProcessingDirection: d.Get("processing_direction").(string),
+		ProcessingMode:      d.Get("processing_mode").(string),
+		Content:             []LdifContent{},
+	}
+
+	for index, rawContent := range d.Get("content").([]interface{}) {
+		content := rawContent.(map[string]interface{})
+		data, err := extractLdifContentData(content["data"].(map[string]interface{}), content["data_json"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("content #%d: %s", index, err)
+		}
+		ldif.Content = append(ldif.Content, LdifContent{
+			Type: content["type"].(string),
+			Id:   content["id"].(string),
+			Data: data,
+		})
+	}
+	return ldif, nil
+}
+
+// Merge the flat attributes and the JSON attributes of a content item.
+// The same attribute must not be set in both.
+func extractLdifContentData(data map[string]interface{}, dataJson string) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	if dataJson != "" {
+		err := json.Unmarshal([]byte(dataJson), &merged)
+		if err != nil {
+			return nil, fmt.Errorf("data_json must be a JSON object: %s", err)
+		}
+		// null unmarshals into a nil map
+		if merged == nil {
+			return nil, fmt.Errorf("data_json must be a JSON object, got: %s", dataJson)
+		}
+	}
+	for key, value := range data {
+		if _, ok := merged[key]; ok {
+			return nil, fmt.Errorf("attribute '%s' is set in both data and data_json", key)
+		}
+		merged[key] = value
+	}
+	return merged, nil
+}
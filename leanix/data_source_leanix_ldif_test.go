@@ -0,0 +1,64 @@
+package leanix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestDataSourceLeanixLdifRead(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceLeanixLdif().Schema, map[string]interface{}{
+		"connector_type":    "ee",
+		"connector_id":      "Kub-Dev-001",
+		"connector_version": "1.2.0",
+		"content": []interface{}{
+			map[string]interface{}{
+				"type":      "Deployment",
+				"id":        "634c16bf-198c-1129-9d08-92630b573fbf",
+				"data":      map[string]interface{}{"app": "Web-based Mail"},
+				"data_json": `{"tags": ["mail"]}`,
+			},
+		},
+	})
+
+	err := dataSourceLeanixLdifRead(d, nil)
+	if err != nil {
+		t.Fatalf("dataSourceLeanixLdifRead() returned an error: %s", err)
+	}
+	assertEqual(t, d.Get("json"), `{"connectorType":"ee","connectorId":"Kub-Dev-001","connectorVersion":"1.2.0","lxVersion":"1.0.0","content":[{"type":"Deployment","id":"634c16bf-198c-1129-9d08-92630b573fbf","data":{"app":"Web-based Mail","tags":["mail"]}}]}`)
+	assertEqual(t, d.Id(), hashLdif(d.Get("json").(string)))
+}
+
+func TestDataSourceLeanixLdifReadInvalid(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceLeanixLdif().Schema, map[string]interface{}{
+		"connector_type": "ee",
+		"connector_id":   "Kub-Dev-001",
+		"content": []interface{}{
+			map[string]interface{}{"type": "Deployment", "id": "1"},
+			map[string]interface{}{"type": "Deployment", "id": "1"},
+		},
+	})
+
+	err := dataSourceLeanixLdifRead(d, nil)
+	if err == nil || !strings.Contains(err.Error(), "content #1 has the same type and id as another content item") {
+		t.Fatalf("Expected an error for duplicate content items but got %v", err)
+	}
+}
+
+func TestExtractLdifContentData(t *testing.T) {
+	_, err := extractLdifContentData(map[string]interface{}{"app": "Mail"}, `{"app": "Web-based Mail"}`)
+	if err == nil {
+		t.Fatal("extractLdifContentData() should fail for attributes set in both data and data_json")
+	}
+
+	_, err = extractLdifContentData(nil, `["not", "an", "object"]`)
+	if err == nil {
+		t.Fatal("extractLdifContentData() should fail if data_json is not an object")
+	}
+
+	_, err = extractLdifContentData(map[string]interface{}{"app": "Mail"}, "null")
+	if err == nil {
+		t.Fatal("extractLdifContentData() should fail if data_json is null")
+	}
+}
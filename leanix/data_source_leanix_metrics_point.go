@@ -0,0 +1,98 @@
+package leanix
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func dataSourceLeanixMetricsPoint() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLeanixMetricsPointRead,
+
+		Schema: map[string]*schema.Schema{
+			"schema_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"start": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"end": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"fact_sheet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"points": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"dimensions": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"metrics": &schema.Schema{
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeFloat,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLeanixMetricsPointRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	schemaId := d.Get("schema_id").(string)
+	start := d.Get("start").(string)
+	end := d.Get("end").(string)
+
+	// the schema tells which values of the points are dimensions and which are metrics
+	metricsSchema, err := leanixClient.ReadMetricsSchema(schemaId)
+	if err != nil {
+		return err
+	}
+	if metricsSchema == nil {
+		return errors.New("Metrics schema '" + schemaId + "' does not exist.")
+	}
+	points, err := leanixClient.QueryMetricsPoints(schemaId, start, end, d.Get("fact_sheet_id").(string))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(schemaId + "/" + start + "/" + end)
+	return d.Set("points", packageMetricsPoints(metricsSchema, points))
+}
+
+func packageMetricsPoints(metricsSchema *MetricsSchema, points []MetricsPoint) []map[string]interface{} {
+	packagedPoints := []map[string]interface{}{}
+	for _, point := range points {
+		dimensions, metrics := point.split(metricsSchema)
+		packagedPoints = append(packagedPoints, map[string]interface{}{
+			"timestamp":  point.Timestamp(),
+			"dimensions": dimensions,
+			"metrics":    metrics,
+		})
+	}
+	return packagedPoints
+}
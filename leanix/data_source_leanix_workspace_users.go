@@ -0,0 +1,91 @@
+package leanix
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceLeanixWorkspaceUsers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceLeanixWorkspaceUsersRead,
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"users": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permission_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"first_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"last_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"role": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLeanixWorkspaceUsersRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	workspaceId := d.Get("workspace_id").(string)
+	permissions, err := leanixClient.ListWorkspacePermissions(workspaceId, "")
+	if err != nil {
+		return err
+	}
+
+	d.SetId(workspaceId)
+	return d.Set("users", packageWorkspacePermissions(permissions))
+}
+
+// Archived permissions belong to users that lost access to the workspace, so they are skipped.
+func packageWorkspacePermissions(permissions []WorkspacePermission) []map[string]interface{} {
+	packagedPermissions := []map[string]interface{}{}
+	for _, permission := range permissions {
+		if permission.Status == "ARCHIVED" {
+			continue
+		}
+		permissionId := ""
+		if permission.Id != nil {
+			permissionId = *permission.Id
+		}
+		packagedPermissions = append(packagedPermissions, map[string]interface{}{
+			"permission_id": permissionId,
+			"user_id":       permission.User.Id,
+			"email":         permission.User.Email,
+			"first_name":    permission.User.FirstName,
+			"last_name":     permission.User.LastName,
+			"role":          permission.Role,
+			"status":        permission.Status,
+		})
+	}
+	return packagedPermissions
+}
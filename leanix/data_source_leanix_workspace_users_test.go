@@ -0,0 +1,40 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestPackageWorkspacePermissions(t *testing.T) {
+	activeId := "active"
+	archivedId := "archived"
+	input := []WorkspacePermission{
+		{
+			Id:          &activeId,
+			WorkspaceId: "ws",
+			User:        WorkspaceUser{Id: "u1", Email: "jane.doe@example.com", FirstName: "Jane", LastName: "Doe"},
+			Role:        "ADMIN",
+			Status:      "ACTIVE",
+		},
+		{
+			Id:          &archivedId,
+			WorkspaceId: "ws",
+			User:        WorkspaceUser{Id: "u2", Email: "john.doe@example.com"},
+			Role:        "MEMBER",
+			Status:      "ARCHIVED",
+		},
+	}
+	expectedOutput := []map[string]interface{}{
+		{
+			"permission_id": "active",
+			"user_id":       "u1",
+			"email":         "jane.doe@example.com",
+			"first_name":    "Jane",
+			"last_name":     "Doe",
+			"role":          "ADMIN",
+			"status":        "ACTIVE",
+		},
+	}
+
+	actualOutput := packageWorkspacePermissions(input)
+	assertEqual(t, actualOutput, expectedOutput)
+}
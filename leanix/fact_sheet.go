@@ -0,0 +1,185 @@
+package leanix
+
+type FactSheet struct {
+	Id   string         `json:"id"`
+	Rev  int64          `json:"rev"`
+	Name string         `json:"name"`
+	Type string         `json:"type"`
+	Tags []FactSheetTag `json:"tags"`
+}
+
+type FactSheetTag struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type FactSheetPatch struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Value string `json:"value"`
+}
+
+const readFactSheetQuery = `query ($id: ID!) {
+  factSheet(id: $id) {
+    id
+    rev
+    name
+    type
+    tags {
+      id
+      name
+    }
+  }
+}`
+
+const updateFactSheetMutation = `mutation ($id: ID!, $patches: [Patch]!) {
+  updateFactSheet(id: $id, patches: $patches, validateOnly: false) {
+    factSheet {
+      id
+      rev
+      name
+      type
+      tags {
+        id
+        name
+      }
+    }
+  }
+}`
+
+type FactSheetSubscription struct {
+	Id    string                      `json:"id"`
+	Type  string                      `json:"type"`
+	User  FactSheetSubscriber         `json:"user"`
+	Roles []FactSheetSubscriptionRole `json:"roles"`
+}
+
+type FactSheetSubscriber struct {
+	Id    string `json:"id"`
+	Email string `json:"email"`
+}
+
+type FactSheetSubscriptionRole struct {
+	Id               string `json:"id"`
+	Name             string `json:"name"`
+	SubscriptionType string `json:"subscriptionType,omitempty"`
+}
+
+const readFactSheetSubscriptionsQuery = `query ($id: ID!) {
+  factSheet(id: $id) {
+    subscriptions {
+      edges {
+        node {
+          id
+          type
+          user {
+            id
+            email
+          }
+          roles {
+            id
+            name
+          }
+        }
+      }
+    }
+  }
+}`
+
+const readSubscriptionRolesQuery = `query {
+  allSubscriptionRoles {
+    edges {
+      node {
+        id
+        name
+        subscriptionType
+      }
+    }
+  }
+}`
+
+const createFactSheetSubscriptionMutation = `mutation ($factSheetId: ID!, $user: UserInput!, $type: SubscriptionType!, $roles: [SubscriptionToSubscriptionRoleLinkInput]) {
+  createSubscription(factSheetId: $factSheetId, user: $user, type: $type, roles: $roles) {
+    id
+    type
+    user {
+      id
+      email
+    }
+    roles {
+      id
+      name
+    }
+  }
+}`
+
+const updateFactSheetSubscriptionMutation = `mutation ($id: ID!, $user: UserInput!, $type: SubscriptionType!, $roles: [SubscriptionToSubscriptionRoleLinkInput]) {
+  updateSubscription(id: $id, user: $user, type: $type, roles: $roles) {
+    id
+    type
+    user {
+      id
+      email
+    }
+    roles {
+      id
+      name
+    }
+  }
+}`
+
+const deleteFactSheetSubscriptionMutation = `mutation ($id: ID!) {
+  deleteSubscription(id: $id) {
+    id
+  }
+}`
+
+type FactSheetDocument struct {
+	Id           string `json:"id"`
+	Name         string `json:"name"`
+	Url          string `json:"url"`
+	Description  string `json:"description"`
+	DocumentType string `json:"documentType"`
+}
+
+const readFactSheetDocumentsQuery = `query ($id: ID!) {
+  factSheet(id: $id) {
+    documents {
+      edges {
+        node {
+          id
+          name
+          url
+          description
+          documentType
+        }
+      }
+    }
+  }
+}`
+
+const createFactSheetDocumentMutation = `mutation ($factSheetId: ID!, $name: String!, $url: String, $description: String, $documentType: String) {
+  createDocument(factSheetId: $factSheetId, name: $name, url: $url, description: $description, documentType: $documentType, validateOnly: false) {
+    id
+    name
+    url
+    description
+    documentType
+  }
+}`
+
+const updateFactSheetDocumentMutation = `mutation ($id: ID!, $patches: [Patch]!) {
+  updateDocument(id: $id, patches: $patches, validateOnly: false) {
+    id
+    name
+    url
+    description
+    documentType
+  }
+}`
+
+const deleteFactSheetDocumentMutation = `mutation ($id: ID!) {
+  deleteDocument(id: $id) {
+    id
+  }
+}`
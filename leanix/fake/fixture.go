@@ -0,0 +1,46 @@
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// A fixture seeds the state of the fake server, e.g. with objects which are
+// expected to exist already when Terraform runs.
+type Fixture struct {
+	WebhookSubscriptions []WebhookSubscription `json:"webhookSubscriptions"`
+}
+
+func ReadFixture(reader io.Reader) (*Fixture, error) {
+	fixture := &Fixture{}
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(fixture); err != nil {
+		return nil, fmt.Errorf("Invalid fixture: %s", err)
+	}
+	return fixture, nil
+}
+
+// Add the objects of the fixture to the state of the server.
+// Objects without ID get a random one.
+func (server *Server) Load(fixture *Fixture) error {
+	server.Lock()
+	defer server.Unlock()
+
+	for _, subscription := range fixture.WebhookSubscriptions {
+		if subscription.identifier() == "" {
+			return fmt.Errorf("Invalid fixture: webhook subscription without identifier")
+		}
+		for _, existing := range server.webhookSubscriptions {
+			if existing.identifier() == subscription.identifier() {
+				return fmt.Errorf("Invalid fixture: duplicate webhook subscription identifier '%s'", subscription.identifier())
+			}
+		}
+		if subscription.id() == "" {
+			subscription["id"] = randomId()
+		}
+		server.webhookSubscriptions[subscription.id()] = subscription
+	}
+	return nil
+}
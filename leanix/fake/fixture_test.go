@@ -0,0 +1,36 @@
+package fake
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadFixture(t *testing.T) {
+	fixture, err := ReadFixture(strings.NewReader(`{
+		"webhookSubscriptions": [
+			{"id": "existing", "identifier": "first"},
+			{"identifier": "second"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := NewServer()
+	if err := server.Load(fixture); err != nil {
+		t.Fatal(err)
+	}
+	if len(server.webhookSubscriptions) != 2 {
+		t.Fatalf("Expected 2 webhook subscriptions, got %d", len(server.webhookSubscriptions))
+	}
+	if server.webhookSubscriptions["existing"].identifier() != "first" {
+		t.Fatal("Expected the ID from the fixture to be kept")
+	}
+
+	if err := server.Load(&Fixture{WebhookSubscriptions: []WebhookSubscription{{"identifier": "first"}}}); err == nil {
+		t.Fatal("Expected a duplicate identifier to be rejected")
+	}
+	if _, err := ReadFixture(strings.NewReader(`{"subscriptions": []}`)); err == nil {
+		t.Fatal("Expected unknown fields to be rejected")
+	}
+}
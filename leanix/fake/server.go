@@ -0,0 +1,241 @@
+// Package fake emulates the parts of the LeanIX API used by the provider with
+// in-memory state, so acceptance tests and local development work without a
+// LeanIX workspace.
+package fake
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const webhookSubscriptionsPath = "/services/webhooks/v1/subscriptions"
+
+// A webhook subscription is kept as generic JSON object, so all fields sent by
+// the client are returned as they are.
+type WebhookSubscription map[string]interface{}
+
+func (subscription WebhookSubscription) id() string {
+	id, _ := subscription["id"].(string)
+	return id
+}
+
+func (subscription WebhookSubscription) identifier() string {
+	identifier, _ := subscription["identifier"].(string)
+	return identifier
+}
+
+type response struct {
+	Status string          `json:"status"`
+	Data   interface{}     `json:"data,omitempty"`
+	Errors []responseError `json:"errors,omitempty"`
+}
+
+type responseError struct {
+	Value string `json:"value"`
+}
+
+// Server is an http.Handler emulating LeanIX.
+// If ApiToken is set, only this API token is exchanged for access tokens.
+// Otherwise any API token is accepted. The access tokens are unsigned JWTs
+// for the workspace WorkspaceId named WorkspaceName.
+type Server struct {
+	ApiToken      string
+	WorkspaceId   string
+	WorkspaceName string
+
+	accessTokens         map[string]bool
+	webhookSubscriptions map[string]WebhookSubscription
+	sync.Mutex
+}
+
+func NewServer() *Server {
+	return &Server{
+		WorkspaceId:          randomId(),
+		WorkspaceName:        "fake",
+		accessTokens:         map[string]bool{},
+		webhookSubscriptions: map[string]WebhookSubscription{},
+	}
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.Lock()
+	defer server.Unlock()
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == "/services/mtm/v1/oauth2/token" {
+		server.issueAccessToken(w, r)
+		return
+	}
+	if !server.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "Invalid or missing access token")
+		return
+	}
+
+	switch {
+	case path == webhookSubscriptionsPath:
+		server.handleWebhookSubscriptions(w, r)
+	case strings.HasPrefix(path, webhookSubscriptionsPath+"/"):
+		server.handleWebhookSubscription(w, r, strings.TrimPrefix(path, webhookSubscriptionsPath+"/"))
+	default:
+		writeError(w, http.StatusNotFound, "The fake LeanIX server does not support "+r.Method+" "+r.URL.Path)
+	}
+}
+
+// Exchange an API token sent as basic auth for an access token, like MTM does.
+func (server *Server) issueAccessToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+	user, apiToken, ok := r.BasicAuth()
+	if !ok || user != "apitoken" || (server.ApiToken != "" && apiToken != server.ApiToken) {
+		writeError(w, http.StatusUnauthorized, "Invalid API token")
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+		writeError(w, http.StatusBadRequest, "grant_type must be client_credentials")
+		return
+	}
+
+	accessToken := server.newAccessToken("http://" + r.Host)
+	server.accessTokens[accessToken] = true
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+func (server *Server) newAccessToken(instanceUrl string) string {
+	claims, _ := json.Marshal(map[string]interface{}{
+		"instanceUrl": instanceUrl,
+		"exp":         time.Now().Add(time.Hour).Unix(),
+		"principal": map[string]interface{}{
+			"id":       randomId(),
+			"username": "apitoken",
+			"role":     "ACCOUNTUSER",
+			"permission": map[string]interface{}{
+				"workspaceId":   server.WorkspaceId,
+				"workspaceName": server.WorkspaceName,
+				"role":          "ADMIN",
+			},
+		},
+	})
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+	// the random signature makes every token unique
+	return header + "." + base64.RawURLEncoding.EncodeToString(claims) + "." + base64.RawURLEncoding.EncodeToString([]byte(randomId()))
+}
+
+func (server *Server) authorized(r *http.Request) bool {
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return server.accessTokens[accessToken]
+}
+
+func (server *Server) handleWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		subscriptions := []WebhookSubscription{}
+		for _, id := range server.sortedWebhookSubscriptionIds() {
+			subscriptions = append(subscriptions, server.webhookSubscriptions[id])
+		}
+		writeJSON(w, http.StatusOK, response{Status: "OK", Data: subscriptions})
+	case http.MethodPost:
+		subscription, ok := decodeWebhookSubscription(w, r)
+		if !ok {
+			return
+		}
+		for _, existing := range server.webhookSubscriptions {
+			if existing.identifier() == subscription.identifier() {
+				writeError(w, http.StatusBadRequest, "A subscription with identifier '"+subscription.identifier()+"' already exists")
+				return
+			}
+		}
+		subscription["id"] = randomId()
+		server.webhookSubscriptions[subscription.id()] = subscription
+		writeJSON(w, http.StatusOK, response{Status: "OK", Data: subscription})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (server *Server) handleWebhookSubscription(w http.ResponseWriter, r *http.Request, id string) {
+	existing, ok := server.webhookSubscriptions[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "No such subscription: "+id)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, response{Status: "OK", Data: existing})
+	case http.MethodPut:
+		subscription, ok := decodeWebhookSubscription(w, r)
+		if !ok {
+			return
+		}
+		subscription["id"] = id
+		server.webhookSubscriptions[id] = subscription
+		writeJSON(w, http.StatusOK, response{Status: "OK", Data: subscription})
+	case http.MethodDelete:
+		delete(server.webhookSubscriptions, id)
+		writeJSON(w, http.StatusOK, response{Status: "OK", Data: existing})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
+	}
+}
+
+func (server *Server) sortedWebhookSubscriptionIds() []string {
+	var ids []string
+	for id := range server.webhookSubscriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func decodeWebhookSubscription(w http.ResponseWriter, r *http.Request) (WebhookSubscription, bool) {
+	subscription := WebhookSubscription{}
+	if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid subscription: "+err.Error())
+		return nil, false
+	}
+	if subscription.identifier() == "" {
+		writeError(w, http.StatusBadRequest, "Invalid subscription: identifier is missing")
+		return nil, false
+	}
+	return subscription, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, response{Status: "ERROR", Errors: []responseError{{Value: message}}})
+}
+
+// Generate a random ID in the format of a UUID like the ones LeanIX uses.
+func randomId() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand only fails if the OS can't provide randomness
+		panic(fmt.Sprintf("Failed to generate a random ID: %s", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// Basic auth header for an API token, as configured for the provider.
+func AuthHeader(apiToken string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte("apitoken:"+apiToken))
+}
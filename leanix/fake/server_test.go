@@ -0,0 +1,110 @@
+package fake
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebhookSubscriptionLifecycle(t *testing.T) {
+	server := NewServer()
+	server.ApiToken = "secret"
+	testServer := httptest.NewServer(server)
+	defer testServer.Close()
+
+	status, _ := request(t, testServer, "POST", "/services/mtm/v1/oauth2/token", AuthHeader("wrong"), "grant_type=client_credentials")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected a wrong API token to be rejected, got status %d", status)
+	}
+	status, body := request(t, testServer, "POST", "/services/mtm/v1/oauth2/token", AuthHeader("secret"), "grant_type=client_credentials")
+	if status != http.StatusOK {
+		t.Fatalf("Expected an access token, got status %d: %v", status, body)
+	}
+	authorization := body["token_type"].(string) + " " + body["access_token"].(string)
+
+	status, _ = request(t, testServer, "GET", webhookSubscriptionsPath, "Bearer unknown", "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected an unknown access token to be rejected, got status %d", status)
+	}
+
+	status, body = request(t, testServer, "POST", webhookSubscriptionsPath, authorization, `{"identifier": "test", "active": true}`)
+	if status != http.StatusOK {
+		t.Fatalf("Expected the subscription to be created, got status %d: %v", status, body)
+	}
+	id := body["data"].(map[string]interface{})["id"].(string)
+
+	status, _ = request(t, testServer, "POST", webhookSubscriptionsPath, authorization, `{"identifier": "test"}`)
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected a duplicate identifier to be rejected, got status %d", status)
+	}
+
+	status, body = request(t, testServer, "PUT", webhookSubscriptionsPath+"/"+id, authorization, `{"identifier": "test", "active": false}`)
+	if status != http.StatusOK {
+		t.Fatalf("Expected the subscription to be updated, got status %d: %v", status, body)
+	}
+	status, body = request(t, testServer, "GET", webhookSubscriptionsPath+"/"+id, authorization, "")
+	if status != http.StatusOK || body["data"].(map[string]interface{})["active"] != false {
+		t.Fatalf("Expected the updated subscription, got status %d: %v", status, body)
+	}
+	status, body = request(t, testServer, "GET", webhookSubscriptionsPath, authorization, "")
+	if status != http.StatusOK || len(body["data"].([]interface{})) != 1 {
+		t.Fatalf("Expected one subscription in the list, got status %d: %v", status, body)
+	}
+
+	status, _ = request(t, testServer, "DELETE", webhookSubscriptionsPath+"/"+id, authorization, "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected the subscription to be deleted, got status %d", status)
+	}
+	status, body = request(t, testServer, "GET", webhookSubscriptionsPath+"/"+id, authorization, "")
+	if status != http.StatusNotFound || !strings.Contains(body["errors"].([]interface{})[0].(map[string]interface{})["value"].(string), "No such subscription") {
+		t.Fatalf("Expected the subscription to be gone, got status %d: %v", status, body)
+	}
+}
+
+func request(t *testing.T, testServer *httptest.Server, method string, path string, authorization string, body string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(method, testServer.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", authorization)
+	if strings.HasPrefix(body, "{") {
+		req.Header.Set("Content-Type", "application/json")
+	} else {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	decoded := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode, decoded
+}
+
+func TestAccessTokenClaims(t *testing.T) {
+	server := NewServer()
+	server.WorkspaceId = "8751abbf-8093-410d-a090-10c7735952cf"
+	testServer := httptest.NewServer(server)
+	defer testServer.Close()
+
+	_, body := request(t, testServer, "POST", "/services/mtm/v1/oauth2/token", AuthHeader("any"), "grant_type=client_credentials")
+	parts := strings.Split(body["access_token"].(string), ".")
+	if len(parts) != 3 {
+		t.Fatalf("Expected a JWT, got %s", body["access_token"])
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(payload), `"workspaceId":"8751abbf-8093-410d-a090-10c7735952cf"`) {
+		t.Fatalf("Expected the workspace in the claims, got %s", payload)
+	}
+}
@@ -0,0 +1,110 @@
+package leanix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+type ProcessorConfigurationKey struct {
+	ConnectorType       string `json:"connectorType"`
+	ConnectorId         string `json:"connectorId"`
+	ConnectorVersion    string `json:"connectorVersion"`
+	ProcessingDirection string `json:"processingDirection"`
+	ProcessingMode      string `json:"processingMode"`
+}
+
+type ProcessorConfiguration struct {
+	ProcessorConfigurationKey
+	Processors json.RawMessage `json:"processors"`
+}
+
+// The ID of a processor configuration has the form
+// '<connectorType>/<connectorId>/<connectorVersion>/<processingDirection>/<processingMode>'.
+func (key ProcessorConfigurationKey) Id() string {
+	return strings.Join([]string{key.ConnectorType, key.ConnectorId, key.ConnectorVersion, key.ProcessingDirection, key.ProcessingMode}, "/")
+}
+
+// The Integration API identifies processor configurations by query parameters.
+func (key ProcessorConfigurationKey) query() string {
+	return url.Values{
+		"connectorType":       {key.ConnectorType},
+		"connectorId":         {key.ConnectorId},
+		"connectorVersion":    {key.ConnectorVersion},
+		"processingDirection": {key.ProcessingDirection},
+		"processingMode":      {key.ProcessingMode},
+	}.Encode()
+}
+
+func parseProcessorConfigurationId(id string) (ProcessorConfigurationKey, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 5 {
+		return ProcessorConfigurationKey{}, errors.New("Invalid processor configuration ID '" + id + "'. Expected '<connectorType>/<connectorId>/<connectorVersion>/<processingDirection>/<processingMode>'.")
+	}
+	for _, part := range parts {
+		if part == "" {
+			return ProcessorConfigurationKey{}, errors.New("Invalid processor configuration ID '" + id + "'. None of the parts may be empty.")
+		}
+	}
+	return ProcessorConfigurationKey{
+		ConnectorType:       parts[0],
+		ConnectorId:         parts[1],
+		ConnectorVersion:    parts[2],
+		ProcessingDirection: parts[3],
+		ProcessingMode:      parts[4],
+	}, nil
+}
+
+// Compare two lists of processors by their processorName and describe the added,
+// removed and changed processors. Processors without a name are identified by
+// their position.
+func diffProcessors(oldProcessors []interface{}, newProcessors []interface{}) []string {
+	oldByName, oldNames := processorsByName(oldProcessors)
+	newByName, newNames := processorsByName(newProcessors)
+
+	var changes []string
+	for _, name := range oldNames {
+		if _, ok := newByName[name]; !ok {
+			changes = append(changes, "- processor "+name)
+		}
+	}
+	for _, name := range newNames {
+		oldProcessor, ok := oldByName[name]
+		if !ok {
+			changes = append(changes, "+ processor "+name)
+		} else if !reflect.DeepEqual(oldProcessor, newByName[name]) {
+			changes = append(changes, "~ processor "+name)
+		}
+	}
+	return changes
+}
+
+func processorsByName(processors []interface{}) (map[string]interface{}, []string) {
+	byName := map[string]interface{}{}
+	var names []string
+	for index, processor := range processors {
+		object, _ := processor.(map[string]interface{})
+		name, _ := object["processorName"].(string)
+		if name == "" {
+			name = fmt.Sprintf("#%d", index)
+		}
+		byName[name] = processor
+		names = append(names, name)
+	}
+	return byName, names
+}
+
+type SynchronizationRun struct {
+	Id string `json:"id"`
+}
+
+type SynchronizationRunStatus struct {
+	Status string `json:"status"`
+}
+
+type SynchronizationRunWarning struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,58 @@
+package leanix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProcessorConfigurationId(t *testing.T) {
+	key := ProcessorConfigurationKey{
+		ConnectorType:       "ee",
+		ConnectorId:         "Kub-Dev-001",
+		ConnectorVersion:    "1.2.0",
+		ProcessingDirection: "inbound",
+		ProcessingMode:      "partial",
+	}
+	assertEqual(t, key.Id(), "ee/Kub-Dev-001/1.2.0/inbound/partial")
+
+	parsedKey, err := parseProcessorConfigurationId(key.Id())
+	if err != nil {
+		t.Fatalf("parseProcessorConfigurationId() returned an error: %s", err)
+	}
+	assertEqual(t, parsedKey, key)
+
+	for _, invalidId := range []string{"", "ee/Kub-Dev-001/1.2.0/inbound", "ee//1.2.0/inbound/partial"} {
+		if _, err := parseProcessorConfigurationId(invalidId); err == nil {
+			t.Errorf("parseProcessorConfigurationId(%q) should return an error", invalidId)
+		}
+	}
+}
+
+func TestDiffProcessors(t *testing.T) {
+	var oldProcessors []interface{}
+	err := json.Unmarshal([]byte(`[
+  {"processorType": "inboundFactSheet", "processorName": "Apps", "type": "Application"},
+  {"processorType": "inboundRelation", "processorName": "Deployments"},
+  {"processorType": "inboundTag"}
+]`), &oldProcessors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var newProcessors []interface{}
+	err = json.Unmarshal([]byte(`[
+  {"processorType": "inboundFactSheet", "processorName": "Apps", "type": "ITComponent"},
+  {"processorType": "inboundTag"},
+  {"processorType": "inboundSubscription", "processorName": "Owners"}
+]`), &newProcessors)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertEqual(t, diffProcessors(oldProcessors, newProcessors), []string{
+		"- processor Deployments",
+		"- processor #2",
+		"~ processor Apps",
+		"+ processor #1",
+		"+ processor Owners",
+	})
+}
@@ -0,0 +1,126 @@
+package leanix
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Normalize a JSON document by removing insignificant whitespace and sorting
+// the keys of all objects. Documents with the same content result in the same
+// string this way.
+func normalizeJson(document string) (string, error) {
+	var parsed interface{}
+	err := json.Unmarshal([]byte(document), &parsed)
+	if err != nil {
+		return "", err
+	}
+	normalized, err := json.Marshal(parsed)
+	if err != nil {
+		return "", err
+	}
+	return string(normalized), nil
+}
+
+// StateFunc storing JSON attributes normalized, so key order and formatting
+// of the configuration don't cause diffs.
+// Invalid JSON is stored as it is and left to the validation.
+func normalizeJsonStateFunc(value interface{}) string {
+	document := value.(string)
+	normalized, err := normalizeJson(document)
+	if err != nil {
+		return document
+	}
+	return normalized
+}
+
+// Reduce a JSON value from LeanIX to the object keys of the configured value,
+// so keys LeanIX adds or fills with defaults don't show up as changes.
+// The items of lists with as many items as configured, e.g. the questions of a
+// survey, are reduced one by one. Other lists and values are kept as they are.
+func projectJson(value interface{}, configured interface{}) interface{} {
+	if valueList, ok := value.([]interface{}); ok {
+		configuredList, configuredOk := configured.([]interface{})
+		if !configuredOk || len(valueList) != len(configuredList) {
+			return value
+		}
+		projected := make([]interface{}, len(valueList))
+		for index, item := range valueList {
+			projected[index] = projectJson(item, configuredList[index])
+		}
+		return projected
+	}
+
+	valueObject, ok := value.(map[string]interface{})
+	configuredObject, configuredOk := configured.(map[string]interface{})
+	if !ok || !configuredOk {
+		return value
+	}
+	projected := map[string]interface{}{}
+	for key, configuredValue := range configuredObject {
+		if keyValue, ok := valueObject[key]; ok {
+			projected[key] = projectJson(keyValue, configuredValue)
+		}
+	}
+	return projected
+}
+
+// Reduce a JSON document from LeanIX to the object keys of the configured
+// document with projectJson and normalize it for the state. Without a
+// configuration, e.g. on import, the complete document is stored.
+func projectJsonDocument(document string, configuredDocument string) string {
+	var configured interface{}
+	if configuredDocument == "" || json.Unmarshal([]byte(configuredDocument), &configured) != nil {
+		return normalizeJsonStateFunc(document)
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		return document
+	}
+	projected, err := json.Marshal(projectJson(value, configured))
+	if err != nil {
+		return document
+	}
+	return string(projected)
+}
+
+// ValidateFunc for attributes holding a JSON array, e.g. a list of actions.
+func validateJsonArrayString(i interface{}, k string) ([]string, []error) {
+	value, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON array: %s", k, err)}
+	}
+	if _, ok := parsed.([]interface{}); !ok {
+		return nil, []error{fmt.Errorf("%q must be a JSON array, got: %s", k, value)}
+	}
+	return nil, nil
+}
+
+// ValidateFunc for attributes holding a JSON object. null and arrays are
+// rejected, as they can't be merged with other attributes.
+func validateJsonObjectString(i interface{}, k string) ([]string, []error) {
+	value, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON object: %s", k, err)}
+	}
+	if _, ok := parsed.(map[string]interface{}); !ok {
+		return nil, []error{fmt.Errorf("%q must be a JSON object, got: %s", k, value)}
+	}
+	return nil, nil
+}
+
+// Normalize a JSON array returned by LeanIX for the state. A missing array is
+// the same as an empty one.
+func normalizeJsonArray(document json.RawMessage) string {
+	if len(document) == 0 || string(document) == "null" {
+		return "[]"
+	}
+	return normalizeJsonStateFunc(string(document))
+}
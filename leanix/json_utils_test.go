@@ -0,0 +1,77 @@
+package leanix
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalizeJson(t *testing.T) {
+	normalized, err := normalizeJson(`{
+  "b": [1, {"d": null, "c": "x"}],
+  "a": true
+}`)
+	if err != nil {
+		t.Fatalf("normalizeJson() returned an error: %s", err)
+	}
+	assertEqual(t, normalized, `{"a":true,"b":[1,{"c":"x","d":null}]}`)
+
+	if _, err := normalizeJson(`{"a":`); err == nil {
+		t.Fatal("normalizeJson() should fail for invalid JSON")
+	}
+	assertEqual(t, normalizeJsonStateFunc(`{"a":`), `{"a":`)
+}
+
+func TestProjectJson(t *testing.T) {
+	value := map[string]interface{}{
+		"a": map[string]interface{}{"b": "x", "added": true},
+		"c": []interface{}{map[string]interface{}{"d": 1, "e": 2}},
+		"f": "y",
+		"g": []interface{}{map[string]interface{}{"h": 1, "id": "q1"}, "i"},
+	}
+	configured := map[string]interface{}{
+		"a":       map[string]interface{}{"b": "z"},
+		"c":       []interface{}{},
+		"g":       []interface{}{map[string]interface{}{"h": 2}, "j"},
+		"missing": "m",
+	}
+	assertEqual(t, projectJson(value, configured), map[string]interface{}{
+		"a": map[string]interface{}{"b": "x"},
+		"c": []interface{}{map[string]interface{}{"d": 1, "e": 2}},
+		"g": []interface{}{map[string]interface{}{"h": 1}, "i"},
+	})
+	assertEqual(t, projectJson("x", map[string]interface{}{}), "x")
+}
+
+func TestProjectJsonDocument(t *testing.T) {
+	document := `{"filters": {"facets": [], "fullTextSearch": ""}, "columns": ["name"], "version": 2}`
+	assertEqual(t, projectJsonDocument(document, `{"filters":{"facets":[]},"columns":["name"]}`), `{"columns":["name"],"filters":{"facets":[]}}`)
+	assertEqual(t, projectJsonDocument(document, ""), `{"columns":["name"],"filters":{"facets":[],"fullTextSearch":""},"version":2}`)
+}
+
+func TestValidateJsonArrayString(t *testing.T) {
+	if _, errs := validateJsonArrayString(`[{"type": "SEND_EMAIL"}]`, "actions"); len(errs) != 0 {
+		t.Fatalf("Expected a JSON array to be valid, got: %v", errs)
+	}
+	for _, value := range []string{`{"type": "SEND_EMAIL"}`, "", "[", "null"} {
+		if _, errs := validateJsonArrayString(value, "actions"); len(errs) == 0 {
+			t.Fatalf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestValidateJsonObjectString(t *testing.T) {
+	if _, errs := validateJsonObjectString(`{"name": "Mail"}`, "data_json"); len(errs) != 0 {
+		t.Fatalf("Expected a JSON object to be valid, got: %v", errs)
+	}
+	for _, value := range []string{`["Mail"]`, "", "{", "null", `"Mail"`} {
+		if _, errs := validateJsonObjectString(value, "data_json"); len(errs) == 0 {
+			t.Fatalf("Expected %q to be invalid", value)
+		}
+	}
+}
+
+func TestNormalizeJsonArray(t *testing.T) {
+	assertEqual(t, normalizeJsonArray(nil), "[]")
+	assertEqual(t, normalizeJsonArray(json.RawMessage("null")), "[]")
+	assertEqual(t, normalizeJsonArray(json.RawMessage(`[ {"b": 1, "a": 2} ]`)), `[{"a":2,"b":1}]`)
+}
@@ -0,0 +1,57 @@
+package leanix
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LeanIX Data Interchange Format, the input of Integration API synchronization runs.
+type Ldif struct {
+	ConnectorType       string        `json:"connectorType"`
+	ConnectorId         string        `json:"connectorId"`
+	ConnectorVersion    string        `json:"connectorVersion,omitempty"`
+	LxVersion           string        `json:"lxVersion,omitempty"`
+	LxWorkspace         string        `json:"lxWorkspace,omitempty"`
+	Description         string        `json:"description,omitempty"`
+	ProcessingDirection string        `json:"processingDirection,omitempty"`
+	ProcessingMode      string        `json:"processingMode,omitempty"`
+	Content             []LdifContent `json:"content"`
+}
+
+type LdifContent struct {
+	Type string                 `json:"type"`
+	Id   string                 `json:"id"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// Validate the structure of an LDIF document.
+// All problems found are returned so they can be fixed at once.
+func validateLdif(ldif Ldif) []error {
+	var errs []error
+	if ldif.ConnectorType == "" {
+		errs = append(errs, errors.New("connectorType must not be empty"))
+	}
+	if ldif.ConnectorId == "" {
+		errs = append(errs, errors.New("connectorId must not be empty"))
+	}
+	if ldif.Content == nil {
+		errs = append(errs, errors.New("content must be a list"))
+	}
+
+	seen := map[string]bool{}
+	for index, content := range ldif.Content {
+		if content.Type == "" {
+			errs = append(errs, fmt.Errorf("content #%d must have a type", index))
+		}
+		if content.Id == "" {
+			errs = append(errs, fmt.Errorf("content #%d must have an id", index))
+		} else if seen[content.Type+"/"+content.Id] {
+			errs = append(errs, fmt.Errorf("content #%d has the same type and id as another content item: %s/%s", index, content.Type, content.Id))
+		}
+		seen[content.Type+"/"+content.Id] = true
+		if content.Data == nil {
+			errs = append(errs, fmt.Errorf("content #%d must have a data object", index))
+		}
+	}
+	return errs
+}
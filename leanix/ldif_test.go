@@ -0,0 +1,38 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestValidateLdif(t *testing.T) {
+	valid := Ldif{
+		ConnectorType: "ee",
+		ConnectorId:   "Kub-Dev-001",
+		Content: []LdifContent{
+			{Type: "Deployment", Id: "634c16bf-198c-1129-9d08-92630b573fbf", Data: map[string]interface{}{"app": "Web-based Mail"}},
+			{Type: "Pod", Id: "634c16bf-198c-1129-9d08-92630b573fbf", Data: map[string]interface{}{}},
+		},
+	}
+	if errs := validateLdif(valid); len(errs) > 0 {
+		t.Fatalf("Expected a valid LDIF but got %v", errs)
+	}
+
+	invalid := Ldif{
+		ConnectorType: "ee",
+		Content: []LdifContent{
+			{Type: "Deployment", Id: "1", Data: map[string]interface{}{}},
+			{Type: "Deployment", Id: "1", Data: map[string]interface{}{}},
+			{Id: "2"},
+		},
+	}
+	var messages []string
+	for _, err := range validateLdif(invalid) {
+		messages = append(messages, err.Error())
+	}
+	assertEqual(t, messages, []string{
+		"connectorId must not be empty",
+		"content #1 has the same type and id as another content item: Deployment/1",
+		"content #2 must have a type",
+		"content #2 must have a data object",
+	})
+}
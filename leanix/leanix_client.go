@@ -2,10 +2,13 @@ package leanix
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -15,33 +18,121 @@ import (
 )
 
 type LeanixClient struct {
-	url                string
-	authHeader         string
-	http               *http.Client
-	authorizationToken *string
+	url                      string
+	authHeader               string
+	http                     *http.Client
+	authorizationToken       *string
+	authorizationTokenExpiry time.Time
+	authorizationClaims      *AccessTokenClaims
+	retryWaitMin             time.Duration
+	retryWaitMax             time.Duration
+	workspaces               map[string]*LeanixClient
+	limiter                  *rateLimiter
+	dataModelLock            sync.Mutex
+	// set on clients derived with withContext, which share the token of their origin
+	origin *LeanixClient
+	ctx    context.Context
 	sync.Mutex
 }
 
 type AuthResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
 }
 
+// Returned if LeanIX doesn't issue an access token for the API token.
+type AuthorizationError struct {
+	Status int
+	Body   []byte
+}
+
+func (err *AuthorizationError) Error() string {
+	return fmt.Sprintf("Status code must be 200 but is %d", err.Status)
+}
+
+// Requests failing with one of these statuses are retried up to maxRetries
+// times with exponential backoff, starting with retryWaitMin.
+var retryableStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// LeanIX answers with these statuses before processing a request, so they are
+// the only ones for which requests which aren't idempotent are retried.
+// A 502 or 504 may come from a gateway after LeanIX created an object already.
+var unprocessedStatus = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusServiceUnavailable: true,
+}
+
+var idempotentMethods = map[string]bool{"GET": true, "HEAD": true, "PUT": true, "DELETE": true}
+
+const maxRetries = 3
+
+const tokenExpiryMargin = time.Minute
+
 type WebhookSubscriptionResponse struct {
 	Status       string               `json:"status"`
 	Subscription *WebhookSubscription `json:"data"`
 }
 
+type TechnicalUserResponse struct {
+	Status        string         `json:"status"`
+	TechnicalUser *TechnicalUser `json:"data"`
+}
+
+type WorkspacePermissionResponse struct {
+	Status     string               `json:"status"`
+	Permission *WorkspacePermission `json:"data"`
+}
+
+// MTM returns lists in pages. Total is the number of items on all pages.
+type WorkspacePermissionListResponse struct {
+	Status      string                `json:"status"`
+	Total       int                   `json:"total"`
+	Permissions []WorkspacePermission `json:"data"`
+}
+
+// Number of items requested per page from paged MTM endpoints.
+const mtmPageSize = 100
+
+type GraphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type GraphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []GraphQLError  `json:"errors,omitempty"`
+}
+
+type GraphQLError struct {
+	Message string `json:"message"`
+}
+
 func NewLeanixClient(url string, authHeader string) *LeanixClient {
+	return NewLeanixClientWithTransport(url, authHeader, nil)
+}
+
+// Create a client sending all requests through the given transport, e.g. to
+// record or replay them in tests. A nil transport uses the default one.
+// The requests are traced and logged before they are passed to the transport.
+func NewLeanixClientWithTransport(url string, authHeader string, transport http.RoundTripper) *LeanixClient {
 	httpClient :=
 		&http.Client{
-			Timeout: time.Second * time.Duration(10),
+			Timeout:   time.Second * time.Duration(10),
+			Transport: newTracingTransport(newLoggingTransport(transport)),
 		}
 	return &LeanixClient{
 		url:                url,
 		authHeader:         authHeader,
 		http:               httpClient,
 		authorizationToken: nil,
+		retryWaitMin:       time.Second,
+		retryWaitMax:       30 * time.Second,
 	}
 }
 
@@ -49,70 +140,151 @@ func NewLeanixClient(url string, authHeader string) *LeanixClient {
 // The OAuth2 token can be used for further requests.
 // This function returns the complete header, including the token type.
 // To avoid requesting a new token while the old one is still valid,
-// we synchronize calls towards this method and cache the token until it
-// becomes invalid.
+// we synchronize calls towards this method and cache the token until shortly
+// before it expires.
 func (leanix *LeanixClient) getAuthorizationHeader() (string, error) {
-	leanix.Lock()
-	defer leanix.Unlock()
-	if leanix.authorizationToken != nil {
-		// TODO here we should check if the token is still valid
-		return *leanix.authorizationToken, nil
-	} else {
-		postUrl := leanix.url + "/services/mtm/v1/oauth2/token"
-		postBody := url.Values{"grant_type": {"client_credentials"}}
-		req, err := http.NewRequest("POST", postUrl, strings.NewReader(postBody.Encode()))
-		req.Header.Add("Authorization", leanix.authHeader)
-		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-		req.Header.Add("Content-Length", strconv.Itoa(len(postBody.Encode())))
-		resp, err := leanix.http.Do(req)
+	tokens := leanix.originClient()
+	tokens.Lock()
+	defer tokens.Unlock()
+	if tokens.authorizationToken != nil && (tokens.authorizationTokenExpiry.IsZero() || time.Now().Before(tokens.authorizationTokenExpiry)) {
+		return *tokens.authorizationToken, nil
+	}
 
-		// Process response
-		if err != nil {
-			return "", err
-		}
+	postBody := url.Values{"grant_type": {"client_credentials"}}.Encode()
+	header := http.Header{}
+	header.Set("Authorization", leanix.authHeader)
+	header.Set("Content-Type", "application/x-www-form-urlencoded")
+	status, respBody, err := leanix.send("POST", leanix.url+"/services/mtm/v1/oauth2/token", header, []byte(postBody))
+	if err != nil {
+		return "", err
+	}
 
-		if resp.StatusCode != 200 {
-			return "", errors.New(fmt.Sprintf("Status code must be 200 but is %d", resp.StatusCode))
+	if status != 200 {
+		return "", &AuthorizationError{Status: status, Body: respBody}
+	}
+
+	authResponse := AuthResponse{}
+	err = json.Unmarshal(respBody, &authResponse)
+	if err != nil {
+		return "", err
+	}
+	newToken := authResponse.TokenType + " " + authResponse.AccessToken
+	tokens.authorizationToken = &newToken
+	// tokens which are no JWT are still fine for authorization
+	tokens.authorizationClaims, _ = decodeAccessToken(authResponse.AccessToken)
+	tokens.authorizationTokenExpiry = time.Time{}
+	lifetime := time.Duration(authResponse.ExpiresIn) * time.Second
+	if lifetime <= 0 && tokens.authorizationClaims != nil && tokens.authorizationClaims.Expiry > 0 {
+		lifetime = time.Until(time.Unix(tokens.authorizationClaims.Expiry, 0))
+	}
+	if lifetime > 0 {
+		// renew the token a bit early, so it doesn't expire while a request is on its way
+		if lifetime > 2*tokenExpiryMargin {
+			lifetime -= tokenExpiryMargin
+		} else {
+			lifetime /= 2
 		}
+		tokens.authorizationTokenExpiry = time.Now().Add(lifetime)
+	}
+	return *tokens.authorizationToken, nil
+}
 
-		defer resp.Body.Close()
+// Check that LeanIX can be reached at the URL of the client and accepts its
+// credentials, by getting an access token. The error explains the most likely
+// misconfiguration.
+func (leanix *LeanixClient) checkConnectivity() error {
+	_, err := leanix.getAuthorizationHeader()
+	if err == nil {
+		return nil
+	}
 
-		authResponse := AuthResponse{}
-		err = json.NewDecoder(resp.Body).Decode(&authResponse)
-		if err != nil {
-			return "", err
-		}
-		newToken := authResponse.TokenType + " " + authResponse.AccessToken
-		leanix.authorizationToken = &newToken
-		return *leanix.authorizationToken, nil
+	var authorizationError *AuthorizationError
+	if !errors.As(err, &authorizationError) {
+		return fmt.Errorf("Cannot reach LeanIX at %s: %s. Check the url or region of the provider.", leanix.url, err)
+	}
+	switch authorizationError.Status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("LeanIX at %s rejected the auth_header (status %d). Check the API token and that it belongs to a workspace of this instance.", leanix.url, authorizationError.Status)
+	case http.StatusNotFound:
+		return fmt.Errorf("%s doesn't look like a LeanIX service URL, as it has no token endpoint (status 404). Use the URL of the services of your region, e.g. %s, or set the region instead.", leanix.url, regionUrl("eu"))
+	default:
+		return fmt.Errorf("Failed to get an access token from LeanIX at %s: %s", leanix.url, err)
 	}
 }
 
-// Create a new webhook subscription at LeanIX.
-// This method needs a valid authorization header so it will attempt to get one.
-func (leanix *LeanixClient) CreateWebhookSubscription(subscription WebhookSubscription) (*WebhookSubscription, error) {
-	authorizationHeader, err := leanix.getAuthorizationHeader()
-	if err != nil {
-		return nil, err
+// Forget the cached token if LeanIX rejected it, so the next request gets a
+// new one. A token which has already been replaced by another request is kept.
+func (leanix *LeanixClient) invalidateAuthorizationHeader(authorizationHeader string) {
+	tokens := leanix.originClient()
+	tokens.Lock()
+	defer tokens.Unlock()
+	if tokens.authorizationToken != nil && *tokens.authorizationToken == authorizationHeader {
+		tokens.authorizationToken = nil
 	}
+}
 
-	postUrl := leanix.url + "/services/webhooks/v1/subscriptions"
-	postBody, err := json.Marshal(subscription)
+// Get the workspace and principal from the claims of the access token.
+func (leanix *LeanixClient) ReadAccessTokenClaims() (*AccessTokenClaims, error) {
+	_, err := leanix.getAuthorizationHeader()
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", postUrl, bytes.NewBuffer(postBody))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", authorizationHeader)
+	tokens := leanix.originClient()
+	tokens.Lock()
+	defer tokens.Unlock()
+	if tokens.authorizationClaims == nil {
+		return nil, errors.New("The access token from LeanIX contains no workspace information.")
+	}
+	return tokens.authorizationClaims, nil
+}
 
-	resp, err := leanix.http.Do(req)
-	if err != nil {
-		return nil, err
+// Get a client sending its requests in the given context, e.g. to trace them
+// as part of a resource operation. It shares the cached token with this client.
+func (leanix *LeanixClient) withContext(ctx context.Context) *LeanixClient {
+	return &LeanixClient{
+		url:          leanix.url,
+		authHeader:   leanix.authHeader,
+		http:         leanix.http,
+		retryWaitMin: leanix.retryWaitMin,
+		retryWaitMax: leanix.retryWaitMax,
+		workspaces:   leanix.workspaces,
+		limiter:      leanix.limiter,
+		origin:       leanix.originClient(),
+		ctx:          ctx,
+	}
+}
+
+// Get the client holding the state shared with the clients derived from it,
+// like the token cache and the data model lock.
+func (leanix *LeanixClient) originClient() *LeanixClient {
+	if leanix.origin != nil {
+		return leanix.origin
+	}
+	return leanix
+}
+
+// Serialize the read-modify-write cycles of the data model and its translations
+// in the workspace of the client. LeanIX only accepts complete documents, so
+// parallel changes of different fields would overwrite each other otherwise.
+// The returned function releases the lock.
+func (leanix *LeanixClient) lockDataModel() func() {
+	origin := leanix.originClient()
+	origin.dataModelLock.Lock()
+	return origin.dataModelLock.Unlock
+}
+
+func (leanix *LeanixClient) context() context.Context {
+	if leanix.ctx != nil {
+		return leanix.ctx
 	}
-	defer resp.Body.Close()
+	return context.Background()
+}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+// Create a new webhook subscription at LeanIX.
+// This method needs a valid authorization header so it will attempt to get one.
+func (leanix *LeanixClient) CreateWebhookSubscription(subscription WebhookSubscription) (*WebhookSubscription, error) {
+	_, bodyBytes, err := leanix.doJSONRequest("POST", "/services/webhooks/v1/subscriptions", subscription)
 	if err != nil {
 		return nil, err
 	}
@@ -133,23 +305,49 @@ func (leanix *LeanixClient) CreateWebhookSubscription(subscription WebhookSubscr
 // Read a new webhook subscription from LeanIX.
 // This method needs a valid authorization header so it will attempt to get one.
 func (leanix *LeanixClient) ReadWebhookSubscription(subscriptionId string) (*WebhookSubscription, error) {
-	authorizationHeader, err := leanix.getAuthorizationHeader()
+	_, bodyBytes, err := leanix.doJSONRequest("GET", "/services/webhooks/v1/subscriptions/"+subscriptionId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionResponse := WebhookSubscriptionResponse{}
+	err = json.Unmarshal(bodyBytes, &subscriptionResponse)
 	if err != nil {
 		return nil, err
 	}
+	if subscriptionResponse.Subscription == nil || subscriptionResponse.Subscription.Id == nil {
+		return nil, errors.New("Failed to read subscription '" + subscriptionId + "'. Maybe it was already deleted outside of Terraform? Here's the response from LeanIX: " + string(bodyBytes))
+	}
+	subscription := subscriptionResponse.Subscription
 
-	getUrl := leanix.url + "/services/webhooks/v1/subscriptions/" + subscriptionId
+	return subscription, nil
+}
 
-	req, err := http.NewRequest("GET", getUrl, nil)
-	req.Header.Add("Authorization", authorizationHeader)
+// Updates an existing webhook subscription at LeanIX.
+// This method needs a valid authorization header so it will attempt to get one.
+func (leanix *LeanixClient) UpdateWebhookSubscription(subscription WebhookSubscription) (*WebhookSubscription, error) {
+	_, bodyBytes, err := leanix.doJSONRequest("PUT", "/services/webhooks/v1/subscriptions/"+*subscription.Id, subscription)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := leanix.http.Do(req)
+	subscriptionResponse := WebhookSubscriptionResponse{}
+	err = json.Unmarshal(bodyBytes, &subscriptionResponse)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if subscriptionResponse.Subscription == nil || subscriptionResponse.Subscription.Id == nil {
+		return nil, errors.New("Failed to update subscription '" + subscription.Identifier + "'. Maybe it was already created outside of Terraform? Here's the response from LeanIX: " + string(bodyBytes))
+	}
+	newSubscription := subscriptionResponse.Subscription
+
+	return newSubscription, nil
+}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+// Delete a webhook subscription at LeanIX.
+// This method needs a valid authorization header so it will attempt to get one.
+func (leanix *LeanixClient) DeleteWebhookSubscription(subscriptionId string) (*WebhookSubscription, error) {
+	_, bodyBytes, err := leanix.doJSONRequest("DELETE", "/services/webhooks/v1/subscriptions/"+subscriptionId, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -160,88 +358,1240 @@ func (leanix *LeanixClient) ReadWebhookSubscription(subscriptionId string) (*Web
 		return nil, err
 	}
 	if subscriptionResponse.Subscription == nil || subscriptionResponse.Subscription.Id == nil {
-		return nil, errors.New("Failed to read subscription '" + subscriptionId + "'. Maybe it was already deleted outside of Terraform? Here's the response from LeanIX: " + string(bodyBytes))
+		return nil, errors.New("Failed to delete subscription with ID'" + subscriptionId + "'. Maybe it was already deleted outside of Terraform? Here's the response from LeanIX: " + string(bodyBytes))
 	}
-	subscription := subscriptionResponse.Subscription
+	deletedSubscription := subscriptionResponse.Subscription
 
-	return subscription, nil
+	return deletedSubscription, nil
 }
 
-// Updates an existing webhook subscription at LeanIX.
+// Send an authorized request with a JSON body to a LeanIX service.
+// The body is marshalled to JSON unless it is nil. The status code and the raw
+// response body are returned so the caller can decide how to handle them.
+func (leanix *LeanixClient) doJSONRequest(method string, path string, body interface{}) (int, []byte, error) {
+	if body == nil {
+		return leanix.doRequest(method, path, "", nil)
+	}
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return leanix.doRequest(method, path, "application/json", bodyBytes)
+}
+
+// Send an authorized request to a LeanIX service.
+// The content type is only set if there is a body.
 // This method needs a valid authorization header so it will attempt to get one.
-func (leanix *LeanixClient) UpdateWebhookSubscription(subscription WebhookSubscription) (*WebhookSubscription, error) {
-	authorizationHeader, err := leanix.getAuthorizationHeader()
+// If LeanIX rejects the token, e.g. because it was revoked, the request is
+// repeated once with a new token.
+func (leanix *LeanixClient) doRequest(method string, path string, contentType string, body []byte) (int, []byte, error) {
+	for refreshed := false; ; refreshed = true {
+		authorizationHeader, err := leanix.getAuthorizationHeader()
+		if err != nil {
+			return 0, nil, err
+		}
+
+		header := http.Header{}
+		if body != nil {
+			header.Set("Content-Type", contentType)
+		}
+		header.Set("Authorization", authorizationHeader)
+
+		status, respBody, err := leanix.send(method, leanix.url+path, header, body)
+		if err == nil && status == http.StatusUnauthorized && !refreshed {
+			leanix.invalidateAuthorizationHeader(authorizationHeader)
+			continue
+		}
+		return status, respBody, err
+	}
+}
+
+// Send a request and read the response, retrying it while LeanIX is
+// overloaded or temporarily unavailable. Failed connections, incomplete
+// responses and gateway errors are only retried for idempotent methods, as the
+// request might have been processed already. Waiting for a retry ends early
+// when the context of the client is done.
+// Each attempt waits for the rate limiter of the client, if there is one.
+func (leanix *LeanixClient) send(method string, url string, header http.Header, body []byte) (int, []byte, error) {
+	wait := leanix.retryWaitMin
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+		req, err := http.NewRequestWithContext(leanix.context(), method, url, reqBody)
+		if err != nil {
+			return 0, nil, err
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+
+		release, err := leanix.limiter.acquire(req.Context())
+		if err != nil {
+			return 0, nil, err
+		}
+		var status int
+		var respBody []byte
+		var retryAfter time.Duration
+		resp, err := leanix.http.Do(req)
+		if err == nil {
+			status = resp.StatusCode
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			respBody, err = ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+		}
+		release()
+
+		retryable := unprocessedStatus[status] || (retryableStatus[status] && idempotentMethods[method])
+		if err != nil {
+			retryable = idempotentMethods[method]
+		}
+		if !retryable || attempt >= maxRetries {
+			if err != nil {
+				return 0, nil, err
+			}
+			return status, respBody, nil
+		}
+
+		if retryAfter > wait {
+			wait = retryAfter
+		}
+		if wait > leanix.retryWaitMax {
+			wait = leanix.retryWaitMax
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return 0, nil, req.Context().Err()
+		}
+		wait *= 2
+	}
+}
+
+// Parse the Retry-After header given in seconds. LeanIX doesn't send dates.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// Execute a query or mutation against the LeanIX GraphQL API and decode the
+// data of the response into result.
+// Errors reported by GraphQL are returned as a single error.
+func (leanix *LeanixClient) executeGraphQL(query string, variables map[string]interface{}, result interface{}) error {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/pathfinder/v1/graphql", GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return err
+	}
+
+	graphQLResponse := GraphQLResponse{}
+	err = json.Unmarshal(respBody, &graphQLResponse)
+	if err != nil {
+		return errors.New("Failed to parse GraphQL response from LeanIX: " + string(respBody))
+	}
+	if len(graphQLResponse.Errors) > 0 {
+		var messages []string
+		for _, graphQLError := range graphQLResponse.Errors {
+			messages = append(messages, graphQLError.Message)
+		}
+		return errors.New("LeanIX GraphQL request failed: " + strings.Join(messages, "; "))
+	}
+	if result == nil || len(graphQLResponse.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(graphQLResponse.Data, result)
+}
+
+// Read a fact sheet including its tags from LeanIX.
+// Returns nil if the fact sheet does not exist.
+func (leanix *LeanixClient) ReadFactSheet(factSheetId string) (*FactSheet, error) {
+	result := struct {
+		FactSheet *FactSheet `json:"factSheet"`
+	}{}
+	err := leanix.executeGraphQL(readFactSheetQuery, map[string]interface{}{"id": factSheetId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.FactSheet, nil
+}
+
+// Assign an existing tag to a fact sheet at LeanIX.
+// Other tags of the fact sheet are left untouched.
+func (leanix *LeanixClient) AddFactSheetTag(factSheetId string, tagId string) (*FactSheet, error) {
+	return leanix.patchFactSheetTags(factSheetId, "add", tagId)
+}
+
+// Remove a tag from a fact sheet at LeanIX.
+// Other tags of the fact sheet are left untouched.
+func (leanix *LeanixClient) RemoveFactSheetTag(factSheetId string, tagId string) (*FactSheet, error) {
+	return leanix.patchFactSheetTags(factSheetId, "remove", tagId)
+}
+
+func (leanix *LeanixClient) patchFactSheetTags(factSheetId string, op string, tagId string) (*FactSheet, error) {
+	value, err := json.Marshal([]map[string]string{{"tagId": tagId}})
 	if err != nil {
 		return nil, err
 	}
+	variables := map[string]interface{}{
+		"id": factSheetId,
+		"patches": []FactSheetPatch{
+			{Op: op, Path: "/tags", Value: string(value)},
+		},
+	}
 
-	putUrl := leanix.url + "/services/webhooks/v1/subscriptions/" + *subscription.Id
-	putBody, err := json.Marshal(subscription)
+	result := struct {
+		UpdateFactSheet struct {
+			FactSheet *FactSheet `json:"factSheet"`
+		} `json:"updateFactSheet"`
+	}{}
+	err = leanix.executeGraphQL(updateFactSheetMutation, variables, &result)
 	if err != nil {
 		return nil, err
 	}
+	if result.UpdateFactSheet.FactSheet == nil {
+		return nil, errors.New("Failed to " + op + " tag '" + tagId + "' on fact sheet '" + factSheetId + "'. Maybe the fact sheet was deleted outside of Terraform?")
+	}
+	return result.UpdateFactSheet.FactSheet, nil
+}
 
-	req, err := http.NewRequest("PUT", putUrl, bytes.NewBuffer(putBody))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Add("Authorization", authorizationHeader)
+// Create a new technical user at LeanIX.
+// The returned technical user contains the generated API token. LeanIX will
+// not return the token again afterwards.
+func (leanix *LeanixClient) CreateTechnicalUser(technicalUser TechnicalUser) (*TechnicalUser, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/mtm/v1/technicalUsers", technicalUser)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := leanix.http.Do(req)
+	technicalUserResponse := TechnicalUserResponse{}
+	err = json.Unmarshal(respBody, &technicalUserResponse)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	if technicalUserResponse.TechnicalUser == nil || technicalUserResponse.TechnicalUser.Id == nil {
+		return nil, errors.New("Failed to create technical user '" + technicalUser.UserName + "'. Maybe it was already created outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return technicalUserResponse.TechnicalUser, nil
+}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+// Read a technical user from LeanIX.
+// Returns nil if the technical user does not exist.
+func (leanix *LeanixClient) ReadTechnicalUser(technicalUserId string) (*TechnicalUser, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/mtm/v1/technicalUsers/"+technicalUserId, nil)
 	if err != nil {
 		return nil, err
 	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
 
-	subscriptionResponse := WebhookSubscriptionResponse{}
-	err = json.Unmarshal(bodyBytes, &subscriptionResponse)
+	technicalUserResponse := TechnicalUserResponse{}
+	err = json.Unmarshal(respBody, &technicalUserResponse)
 	if err != nil {
 		return nil, err
 	}
-	if subscriptionResponse.Subscription == nil || subscriptionResponse.Subscription.Id == nil {
-		return nil, errors.New("Failed to update subscription '" + subscription.Identifier + "'. Maybe it was already created outside of Terraform? Here's the response from LeanIX: " + string(bodyBytes))
+	if technicalUserResponse.TechnicalUser == nil || technicalUserResponse.TechnicalUser.Id == nil {
+		return nil, errors.New("Failed to read technical user '" + technicalUserId + "'. Here's the response from LeanIX: " + string(respBody))
 	}
-	newSubscription := subscriptionResponse.Subscription
+	return technicalUserResponse.TechnicalUser, nil
+}
 
-	return newSubscription, nil
+// Update an existing technical user at LeanIX.
+// The API token of the technical user stays the same.
+func (leanix *LeanixClient) UpdateTechnicalUser(technicalUser TechnicalUser) (*TechnicalUser, error) {
+	_, respBody, err := leanix.doJSONRequest("PUT", "/services/mtm/v1/technicalUsers/"+*technicalUser.Id, technicalUser)
+	if err != nil {
+		return nil, err
+	}
+
+	technicalUserResponse := TechnicalUserResponse{}
+	err = json.Unmarshal(respBody, &technicalUserResponse)
+	if err != nil {
+		return nil, err
+	}
+	if technicalUserResponse.TechnicalUser == nil || technicalUserResponse.TechnicalUser.Id == nil {
+		return nil, errors.New("Failed to update technical user '" + technicalUser.UserName + "'. Maybe it was deleted outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return technicalUserResponse.TechnicalUser, nil
 }
 
-// Delete a webhook subscription at LeanIX.
-// This method needs a valid authorization header so it will attempt to get one.
-func (leanix *LeanixClient) DeleteWebhookSubscription(subscriptionId string) (*WebhookSubscription, error) {
-	authorizationHeader, err := leanix.getAuthorizationHeader()
+// Replace the API token of a technical user at LeanIX.
+// The old token becomes invalid immediately and the returned technical user
+// contains the new token.
+func (leanix *LeanixClient) ReplaceTechnicalUserApiToken(technicalUserId string) (*TechnicalUser, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/mtm/v1/technicalUsers/"+technicalUserId+"/replaceApiToken", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	technicalUserResponse := TechnicalUserResponse{}
+	err = json.Unmarshal(respBody, &technicalUserResponse)
 	if err != nil {
 		return nil, err
 	}
+	if technicalUserResponse.TechnicalUser == nil || technicalUserResponse.TechnicalUser.ApiToken == "" {
+		return nil, errors.New("Failed to replace API token of technical user '" + technicalUserId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return technicalUserResponse.TechnicalUser, nil
+}
 
-	deleteUrl := leanix.url + "/services/webhooks/v1/subscriptions/" + subscriptionId
+// Delete a technical user at LeanIX.
+// Its API token becomes invalid immediately.
+func (leanix *LeanixClient) DeleteTechnicalUser(technicalUserId string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/mtm/v1/technicalUsers/"+technicalUserId, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete technical user with ID '" + technicalUserId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
 
-	req, err := http.NewRequest("DELETE", deleteUrl, nil)
-	req.Header.Add("Authorization", authorizationHeader)
+// Invite a user by email to a workspace at LeanIX.
+// Returns the permission of the user in the workspace, which is created with
+// the status 'INVITED' for users not yet known to LeanIX.
+func (leanix *LeanixClient) InviteWorkspaceUser(invitation WorkspaceInvitation) (*WorkspacePermission, error) {
+	status, respBody, err := leanix.doJSONRequest("POST", "/services/mtm/v1/idm/invite", invitation)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.New("Failed to invite user '" + invitation.User.Email + "'. Here's the response from LeanIX: " + string(respBody))
+	}
 
-	resp, err := leanix.http.Do(req)
+	permissions, err := leanix.ListWorkspacePermissions(invitation.Permission.WorkspaceId, invitation.User.Email)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	for _, permission := range permissions {
+		if strings.EqualFold(permission.User.Email, invitation.User.Email) && permission.Status != "ARCHIVED" {
+			return &permission, nil
+		}
+	}
+	return nil, errors.New("User '" + invitation.User.Email + "' was invited but has no permission in workspace '" + invitation.Permission.WorkspaceId + "'.")
+}
+
+// List the permissions of all users in a workspace at LeanIX.
+// If email is not empty, only the permissions of that user are returned.
+// The pages are read until the last one, which is shorter than a full page or
+// completes the total number of permissions.
+func (leanix *LeanixClient) ListWorkspacePermissions(workspaceId string, email string) ([]WorkspacePermission, error) {
+	permissions := []WorkspacePermission{}
+	for page := 1; ; page++ {
+		query := url.Values{"page": {strconv.Itoa(page)}, "size": {strconv.Itoa(mtmPageSize)}}
+		if email != "" {
+			query.Set("email", email)
+		}
+		getPath := "/services/mtm/v1/workspaces/" + workspaceId + "/permissions?" + query.Encode()
+		_, respBody, err := leanix.doJSONRequest("GET", getPath, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		permissionListResponse := WorkspacePermissionListResponse{}
+		err = json.Unmarshal(respBody, &permissionListResponse)
+		if err != nil {
+			return nil, err
+		}
+		if permissionListResponse.Permissions == nil {
+			return nil, errors.New("Failed to list permissions of workspace '" + workspaceId + "'. Here's the response from LeanIX: " + string(respBody))
+		}
+		permissions = append(permissions, permissionListResponse.Permissions...)
+
+		if len(permissionListResponse.Permissions) < mtmPageSize || (permissionListResponse.Total > 0 && len(permissions) >= permissionListResponse.Total) {
+			return permissions, nil
+		}
+	}
+}
 
-	bodyBytes, err := ioutil.ReadAll(resp.Body)
+// Read the permission of a user in a workspace from LeanIX.
+// Returns nil if the permission does not exist.
+func (leanix *LeanixClient) ReadWorkspacePermission(permissionId string) (*WorkspacePermission, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/mtm/v1/permissions/"+permissionId, nil)
 	if err != nil {
 		return nil, err
 	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
 
-	subscriptionResponse := WebhookSubscriptionResponse{}
-	err = json.Unmarshal(bodyBytes, &subscriptionResponse)
+	permissionResponse := WorkspacePermissionResponse{}
+	err = json.Unmarshal(respBody, &permissionResponse)
 	if err != nil {
 		return nil, err
 	}
-	if subscriptionResponse.Subscription == nil || subscriptionResponse.Subscription.Id == nil {
-		return nil, errors.New("Failed to delete subscription with ID'" + subscriptionId + "'. Maybe it was already deleted outside of Terraform? Here's the response from LeanIX: " + string(bodyBytes))
+	if permissionResponse.Permission == nil || permissionResponse.Permission.Id == nil {
+		return nil, errors.New("Failed to read permission '" + permissionId + "'. Here's the response from LeanIX: " + string(respBody))
 	}
-	deletedSubscription := subscriptionResponse.Subscription
+	return permissionResponse.Permission, nil
+}
 
-	return deletedSubscription, nil
+// Update the permission of a user in a workspace at LeanIX, e.g. to change the role.
+func (leanix *LeanixClient) UpdateWorkspacePermission(permission WorkspacePermission) (*WorkspacePermission, error) {
+	_, respBody, err := leanix.doJSONRequest("PUT", "/services/mtm/v1/permissions/"+*permission.Id, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	permissionResponse := WorkspacePermissionResponse{}
+	err = json.Unmarshal(respBody, &permissionResponse)
+	if err != nil {
+		return nil, err
+	}
+	if permissionResponse.Permission == nil || permissionResponse.Permission.Id == nil {
+		return nil, errors.New("Failed to update permission '" + *permission.Id + "'. Maybe it was removed outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return permissionResponse.Permission, nil
+}
+
+// Remove the access of a user to a workspace at LeanIX.
+// LeanIX does not delete permissions but archives them.
+func (leanix *LeanixClient) ArchiveWorkspacePermission(permissionId string) error {
+	permission, err := leanix.ReadWorkspacePermission(permissionId)
+	if err != nil {
+		return err
+	}
+	if permission == nil || permission.Status == "ARCHIVED" {
+		return nil
+	}
+
+	permission.Status = "ARCHIVED"
+	_, err = leanix.UpdateWorkspacePermission(*permission)
+	return err
+}
+
+// Read the complete data model of the workspace from LeanIX.
+func (leanix *LeanixClient) ReadDataModel() (DataModel, error) {
+	_, respBody, err := leanix.doJSONRequest("GET", "/services/pathfinder/v1/models/dataModel", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dataModelResponse := DataModelResponse{}
+	err = json.Unmarshal(respBody, &dataModelResponse)
+	if err != nil {
+		return nil, err
+	}
+	if dataModelResponse.DataModel == nil {
+		return nil, errors.New("Failed to read data model. Here's the response from LeanIX: " + string(respBody))
+	}
+	return dataModelResponse.DataModel, nil
+}
+
+// Replace the complete data model of the workspace at LeanIX.
+// LeanIX rejects changes deleting data from fact sheets unless force is set.
+func (leanix *LeanixClient) UpdateDataModel(dataModel DataModel, force bool) (DataModel, error) {
+	putPath := "/services/pathfinder/v1/models/dataModel?force=" + strconv.FormatBool(force)
+	_, respBody, err := leanix.doJSONRequest("PUT", putPath, dataModel)
+	if err != nil {
+		return nil, err
+	}
+
+	dataModelResponse := DataModelResponse{}
+	err = json.Unmarshal(respBody, &dataModelResponse)
+	if err != nil {
+		return nil, err
+	}
+	if dataModelResponse.DataModel == nil {
+		return nil, errors.New("Failed to update data model. Here's the response from LeanIX: " + string(respBody))
+	}
+	return dataModelResponse.DataModel, nil
+}
+
+// Read the translations of the data model for one language from LeanIX.
+func (leanix *LeanixClient) ReadDataModelTranslations(language string) (DataModel, error) {
+	getPath := "/services/pathfinder/v1/models/translations?" + url.Values{"language": {language}}.Encode()
+	_, respBody, err := leanix.doJSONRequest("GET", getPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	translationsResponse := DataModelResponse{}
+	err = json.Unmarshal(respBody, &translationsResponse)
+	if err != nil {
+		return nil, err
+	}
+	if translationsResponse.DataModel == nil {
+		return nil, errors.New("Failed to read data model translations for language '" + language + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return translationsResponse.DataModel, nil
+}
+
+// Replace the translations of the data model for one language at LeanIX.
+func (leanix *LeanixClient) UpdateDataModelTranslations(language string, translations DataModel) error {
+	putPath := "/services/pathfinder/v1/models/translations?" + url.Values{"language": {language}}.Encode()
+	status, respBody, err := leanix.doJSONRequest("PUT", putPath, translations)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return errors.New("Failed to update data model translations for language '" + language + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Create or replace a processor configuration of the Integration API at LeanIX.
+func (leanix *LeanixClient) UpsertProcessorConfiguration(configuration ProcessorConfiguration) (*ProcessorConfiguration, error) {
+	status, respBody, err := leanix.doJSONRequest("PUT", "/services/integration-api/v1/configurations", configuration)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.New("Failed to save processor configuration '" + configuration.Id() + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+
+	savedConfiguration := ProcessorConfiguration{}
+	err = json.Unmarshal(respBody, &savedConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	return &savedConfiguration, nil
+}
+
+// Read a processor configuration of the Integration API from LeanIX.
+// Returns nil if the processor configuration does not exist.
+func (leanix *LeanixClient) ReadProcessorConfiguration(key ProcessorConfigurationKey) (*ProcessorConfiguration, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/integration-api/v1/configurations?"+key.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	if status != http.StatusOK {
+		return nil, errors.New("Failed to read processor configuration '" + key.Id() + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+
+	configuration := ProcessorConfiguration{}
+	err = json.Unmarshal(respBody, &configuration)
+	if err != nil {
+		return nil, err
+	}
+	return &configuration, nil
+}
+
+// Delete a processor configuration of the Integration API at LeanIX.
+func (leanix *LeanixClient) DeleteProcessorConfiguration(key ProcessorConfigurationKey) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/integration-api/v1/configurations?"+key.query(), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete processor configuration '" + key.Id() + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Create a synchronization run of the Integration API at LeanIX for the given
+// LDIF document and start it right away.
+func (leanix *LeanixClient) StartSynchronizationRun(ldif json.RawMessage) (*SynchronizationRun, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/integration-api/v1/synchronizationRuns?start=true", ldif)
+	if err != nil {
+		return nil, err
+	}
+
+	run := SynchronizationRun{}
+	err = json.Unmarshal(respBody, &run)
+	if err != nil {
+		return nil, err
+	}
+	if run.Id == "" {
+		return nil, errors.New("Failed to start synchronization run. Here's the response from LeanIX: " + string(respBody))
+	}
+	return &run, nil
+}
+
+// Read the status of a synchronization run of the Integration API from LeanIX.
+// Returns nil if the run does not exist (anymore).
+func (leanix *LeanixClient) ReadSynchronizationRunStatus(runId string) (*SynchronizationRunStatus, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/integration-api/v1/synchronizationRuns/"+runId+"/status", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	runStatus := SynchronizationRunStatus{}
+	err = json.Unmarshal(respBody, &runStatus)
+	if err != nil {
+		return nil, err
+	}
+	if runStatus.Status == "" {
+		return nil, errors.New("Failed to read status of synchronization run '" + runId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return &runStatus, nil
+}
+
+// Read the warnings of a synchronization run of the Integration API from LeanIX.
+func (leanix *LeanixClient) ReadSynchronizationRunWarnings(runId string) ([]SynchronizationRunWarning, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/integration-api/v1/synchronizationRuns/"+runId+"/warnings", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.New("Failed to read warnings of synchronization run '" + runId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+
+	var warnings []SynchronizationRunWarning
+	err = json.Unmarshal(respBody, &warnings)
+	if err != nil {
+		return nil, err
+	}
+	return warnings, nil
+}
+
+// Read the statistics of a synchronization run of the Integration API from LeanIX,
+// e.g. the number of processed content items or created fact sheets.
+func (leanix *LeanixClient) ReadSynchronizationRunStats(runId string) (map[string]interface{}, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/integration-api/v1/synchronizationRuns/"+runId+"/stats", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.New("Failed to read statistics of synchronization run '" + runId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+
+	stats := map[string]interface{}{}
+	err = json.Unmarshal(respBody, &stats)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Create a new metrics schema at LeanIX.
+func (leanix *LeanixClient) CreateMetricsSchema(metricsSchema MetricsSchema) (*MetricsSchema, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/metrics/v2/schemas", metricsSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	createdSchema := MetricsSchema{}
+	err = json.Unmarshal(respBody, &createdSchema)
+	if err != nil {
+		return nil, err
+	}
+	if createdSchema.Id == nil {
+		return nil, errors.New("Failed to create metrics schema '" + metricsSchema.Name + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return &createdSchema, nil
+}
+
+// Read a metrics schema from LeanIX.
+// Returns nil if the metrics schema does not exist.
+func (leanix *LeanixClient) ReadMetricsSchema(schemaId string) (*MetricsSchema, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/metrics/v2/schemas/"+schemaId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	metricsSchema := MetricsSchema{}
+	err = json.Unmarshal(respBody, &metricsSchema)
+	if err != nil {
+		return nil, err
+	}
+	if metricsSchema.Id == nil {
+		return nil, errors.New("Failed to read metrics schema '" + schemaId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return &metricsSchema, nil
+}
+
+// Delete a metrics schema including all of its points at LeanIX.
+func (leanix *LeanixClient) DeleteMetricsSchema(schemaId string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/metrics/v2/schemas/"+schemaId, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete metrics schema '" + schemaId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Write a point to a metrics schema at LeanIX.
+func (leanix *LeanixClient) CreateMetricsPoint(schemaId string, point MetricsPoint) error {
+	status, respBody, err := leanix.doJSONRequest("POST", "/services/metrics/v2/schemas/"+schemaId+"/points", point)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusCreated && status != http.StatusNoContent {
+		return errors.New("Failed to write metrics point at " + point.Timestamp() + " to schema '" + schemaId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Query the points of a metrics schema between start and end (both inclusive) from LeanIX.
+// If factSheetId is not empty, only the points of that fact sheet are returned.
+func (leanix *LeanixClient) QueryMetricsPoints(schemaId string, start string, end string, factSheetId string) ([]MetricsPoint, error) {
+	query := url.Values{"start": {start}, "end": {end}}
+	if factSheetId != "" {
+		query.Set("factSheetId", factSheetId)
+	}
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/metrics/v2/schemas/"+schemaId+"/points?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, errors.New("Failed to query metrics points of schema '" + schemaId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+
+	var points []MetricsPoint
+	err = json.Unmarshal(respBody, &points)
+	if err != nil {
+		return nil, err
+	}
+	return points, nil
+}
+
+// Delete all points of a metrics schema between start and end (both inclusive) at LeanIX.
+func (leanix *LeanixClient) DeleteMetricsPoints(schemaId string, start string, end string) error {
+	query := url.Values{"start": {start}, "end": {end}}
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/metrics/v2/schemas/"+schemaId+"/points?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete metrics points of schema '" + schemaId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Read the subscriptions of a fact sheet from LeanIX.
+// Returns nil if the fact sheet does not exist.
+func (leanix *LeanixClient) ReadFactSheetSubscriptions(factSheetId string) ([]FactSheetSubscription, error) {
+	result := struct {
+		FactSheet *struct {
+			Subscriptions struct {
+				Edges []struct {
+					Node FactSheetSubscription `json:"node"`
+				} `json:"edges"`
+			} `json:"subscriptions"`
+		} `json:"factSheet"`
+	}{}
+	err := leanix.executeGraphQL(readFactSheetSubscriptionsQuery, map[string]interface{}{"id": factSheetId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.FactSheet == nil {
+		return nil, nil
+	}
+
+	subscriptions := []FactSheetSubscription{}
+	for _, edge := range result.FactSheet.Subscriptions.Edges {
+		subscriptions = append(subscriptions, edge.Node)
+	}
+	return subscriptions, nil
+}
+
+// Read all subscription roles defined in the workspace from LeanIX.
+func (leanix *LeanixClient) ReadSubscriptionRoles() ([]FactSheetSubscriptionRole, error) {
+	result := struct {
+		AllSubscriptionRoles struct {
+			Edges []struct {
+				Node FactSheetSubscriptionRole `json:"node"`
+			} `json:"edges"`
+		} `json:"allSubscriptionRoles"`
+	}{}
+	err := leanix.executeGraphQL(readSubscriptionRolesQuery, nil, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []FactSheetSubscriptionRole{}
+	for _, edge := range result.AllSubscriptionRoles.Edges {
+		roles = append(roles, edge.Node)
+	}
+	return roles, nil
+}
+
+// Subscribe a user to a fact sheet at LeanIX. The user is identified by email.
+func (leanix *LeanixClient) CreateFactSheetSubscription(factSheetId string, email string, subscriptionType string, roleIds []string) (*FactSheetSubscription, error) {
+	variables := map[string]interface{}{
+		"factSheetId": factSheetId,
+		"user":        map[string]string{"email": email},
+		"type":        subscriptionType,
+		"roles":       subscriptionRoleLinks(roleIds),
+	}
+	result := struct {
+		CreateSubscription *FactSheetSubscription `json:"createSubscription"`
+	}{}
+	err := leanix.executeGraphQL(createFactSheetSubscriptionMutation, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.CreateSubscription == nil || result.CreateSubscription.Id == "" {
+		return nil, errors.New("Failed to subscribe '" + email + "' to fact sheet '" + factSheetId + "'.")
+	}
+	return result.CreateSubscription, nil
+}
+
+// Change the type and roles of a subscription at LeanIX.
+func (leanix *LeanixClient) UpdateFactSheetSubscription(subscriptionId string, email string, subscriptionType string, roleIds []string) (*FactSheetSubscription, error) {
+	variables := map[string]interface{}{
+		"id":    subscriptionId,
+		"user":  map[string]string{"email": email},
+		"type":  subscriptionType,
+		"roles": subscriptionRoleLinks(roleIds),
+	}
+	result := struct {
+		UpdateSubscription *FactSheetSubscription `json:"updateSubscription"`
+	}{}
+	err := leanix.executeGraphQL(updateFactSheetSubscriptionMutation, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.UpdateSubscription == nil || result.UpdateSubscription.Id == "" {
+		return nil, errors.New("Failed to update subscription '" + subscriptionId + "'. Maybe it was deleted outside of Terraform?")
+	}
+	return result.UpdateSubscription, nil
+}
+
+// Delete a subscription of a fact sheet at LeanIX.
+func (leanix *LeanixClient) DeleteFactSheetSubscription(subscriptionId string) error {
+	return leanix.executeGraphQL(deleteFactSheetSubscriptionMutation, map[string]interface{}{"id": subscriptionId}, nil)
+}
+
+func subscriptionRoleLinks(roleIds []string) []map[string]string {
+	links := []map[string]string{}
+	for _, roleId := range roleIds {
+		links = append(links, map[string]string{"roleId": roleId})
+	}
+	return links
+}
+
+// Read the documents of a fact sheet from LeanIX.
+// Returns nil if the fact sheet does not exist.
+func (leanix *LeanixClient) ReadFactSheetDocuments(factSheetId string) ([]FactSheetDocument, error) {
+	result := struct {
+		FactSheet *struct {
+			Documents struct {
+				Edges []struct {
+					Node FactSheetDocument `json:"node"`
+				} `json:"edges"`
+			} `json:"documents"`
+		} `json:"factSheet"`
+	}{}
+	err := leanix.executeGraphQL(readFactSheetDocumentsQuery, map[string]interface{}{"id": factSheetId}, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.FactSheet == nil {
+		return nil, nil
+	}
+
+	documents := []FactSheetDocument{}
+	for _, edge := range result.FactSheet.Documents.Edges {
+		documents = append(documents, edge.Node)
+	}
+	return documents, nil
+}
+
+// Attach a new document, e.g. a link, to a fact sheet at LeanIX.
+func (leanix *LeanixClient) CreateFactSheetDocument(factSheetId string, document FactSheetDocument) (*FactSheetDocument, error) {
+	variables := map[string]interface{}{
+		"factSheetId":  factSheetId,
+		"name":         document.Name,
+		"url":          document.Url,
+		"description":  document.Description,
+		"documentType": document.DocumentType,
+	}
+	result := struct {
+		CreateDocument *FactSheetDocument `json:"createDocument"`
+	}{}
+	err := leanix.executeGraphQL(createFactSheetDocumentMutation, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.CreateDocument == nil || result.CreateDocument.Id == "" {
+		return nil, errors.New("Failed to create document '" + document.Name + "' on fact sheet '" + factSheetId + "'.")
+	}
+	return result.CreateDocument, nil
+}
+
+// Update the name, URL, description and type of a document at LeanIX.
+func (leanix *LeanixClient) UpdateFactSheetDocument(document FactSheetDocument) (*FactSheetDocument, error) {
+	variables := map[string]interface{}{
+		"id": document.Id,
+		"patches": []FactSheetPatch{
+			{Op: "replace", Path: "/name", Value: document.Name},
+			{Op: "replace", Path: "/url", Value: document.Url},
+			{Op: "replace", Path: "/description", Value: document.Description},
+			{Op: "replace", Path: "/documentType", Value: document.DocumentType},
+		},
+	}
+	result := struct {
+		UpdateDocument *FactSheetDocument `json:"updateDocument"`
+	}{}
+	err := leanix.executeGraphQL(updateFactSheetDocumentMutation, variables, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.UpdateDocument == nil || result.UpdateDocument.Id == "" {
+		return nil, errors.New("Failed to update document '" + document.Id + "'. Maybe it was deleted outside of Terraform?")
+	}
+	return result.UpdateDocument, nil
+}
+
+// Delete a document of a fact sheet at LeanIX.
+func (leanix *LeanixClient) DeleteFactSheetDocument(documentId string) error {
+	return leanix.executeGraphQL(deleteFactSheetDocumentMutation, map[string]interface{}{"id": documentId}, nil)
+}
+
+// Upload a custom report bundle to LeanIX.
+// Uploading a new version of an existing report creates a new entry with its own ID.
+func (leanix *LeanixClient) UploadCustomReport(bundle []byte) (*CustomReport, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "bundle.zip")
+	if err != nil {
+		return nil, err
+	}
+	_, err = part.Write(bundle)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	_, respBody, err := leanix.doRequest("POST", "/services/pathfinder/v1/reports/upload", writer.FormDataContentType(), body.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	reportResponse := CustomReportResponse{}
+	err = json.Unmarshal(respBody, &reportResponse)
+	if err != nil {
+		return nil, err
+	}
+	if reportResponse.Report == nil || reportResponse.Report.Id == "" {
+		return nil, errors.New("Failed to upload custom report. Here's the response from LeanIX: " + string(respBody))
+	}
+	return reportResponse.Report, nil
+}
+
+// Read a custom report from LeanIX.
+// Returns nil if the custom report does not exist.
+func (leanix *LeanixClient) ReadCustomReport(id string) (*CustomReport, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/pathfinder/v1/reports/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	reportResponse := CustomReportResponse{}
+	err = json.Unmarshal(respBody, &reportResponse)
+	if err != nil {
+		return nil, err
+	}
+	if reportResponse.Report == nil || reportResponse.Report.Id == "" {
+		return nil, errors.New("Failed to read custom report '" + id + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return reportResponse.Report, nil
+}
+
+// Delete a custom report at LeanIX.
+func (leanix *LeanixClient) DeleteCustomReport(id string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/pathfinder/v1/reports/"+id, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete custom report '" + id + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Create a new bookmark, i.e. a saved view, report or dashboard, at LeanIX.
+func (leanix *LeanixClient) CreateBookmark(bookmark Bookmark) (*Bookmark, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/pathfinder/v1/bookmarks", bookmark)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarkResponse := BookmarkResponse{}
+	err = json.Unmarshal(respBody, &bookmarkResponse)
+	if err != nil {
+		return nil, err
+	}
+	if bookmarkResponse.Bookmark == nil || bookmarkResponse.Bookmark.Id == nil {
+		return nil, errors.New("Failed to create bookmark '" + bookmark.Name + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return bookmarkResponse.Bookmark, nil
+}
+
+// Read a bookmark from LeanIX.
+// Returns nil if the bookmark does not exist.
+func (leanix *LeanixClient) ReadBookmark(bookmarkId string) (*Bookmark, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/pathfinder/v1/bookmarks/"+bookmarkId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	bookmarkResponse := BookmarkResponse{}
+	err = json.Unmarshal(respBody, &bookmarkResponse)
+	if err != nil {
+		return nil, err
+	}
+	if bookmarkResponse.Bookmark == nil || bookmarkResponse.Bookmark.Id == nil {
+		return nil, errors.New("Failed to read bookmark '" + bookmarkId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return bookmarkResponse.Bookmark, nil
+}
+
+// Update an existing bookmark at LeanIX.
+func (leanix *LeanixClient) UpdateBookmark(bookmark Bookmark) (*Bookmark, error) {
+	_, respBody, err := leanix.doJSONRequest("PUT", "/services/pathfinder/v1/bookmarks/"+*bookmark.Id, bookmark)
+	if err != nil {
+		return nil, err
+	}
+
+	bookmarkResponse := BookmarkResponse{}
+	err = json.Unmarshal(respBody, &bookmarkResponse)
+	if err != nil {
+		return nil, err
+	}
+	if bookmarkResponse.Bookmark == nil || bookmarkResponse.Bookmark.Id == nil {
+		return nil, errors.New("Failed to update bookmark '" + bookmark.Name + "'. Maybe it was deleted outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return bookmarkResponse.Bookmark, nil
+}
+
+// Delete a bookmark at LeanIX.
+func (leanix *LeanixClient) DeleteBookmark(bookmarkId string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/pathfinder/v1/bookmarks/"+bookmarkId, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete bookmark '" + bookmarkId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Create a new poll, i.e. a survey, at LeanIX.
+func (leanix *LeanixClient) CreatePoll(poll Poll) (*Poll, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/poll/v2/polls", poll)
+	if err != nil {
+		return nil, err
+	}
+
+	pollResponse := PollResponse{}
+	err = json.Unmarshal(respBody, &pollResponse)
+	if err != nil {
+		return nil, err
+	}
+	if pollResponse.Poll == nil || pollResponse.Poll.Id == nil {
+		return nil, errors.New("Failed to create poll '" + poll.Title + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return pollResponse.Poll, nil
+}
+
+// Read a poll from LeanIX.
+// Returns nil if the poll does not exist.
+func (leanix *LeanixClient) ReadPoll(pollId string) (*Poll, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/poll/v2/polls/"+pollId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	pollResponse := PollResponse{}
+	err = json.Unmarshal(respBody, &pollResponse)
+	if err != nil {
+		return nil, err
+	}
+	if pollResponse.Poll == nil || pollResponse.Poll.Id == nil {
+		return nil, errors.New("Failed to read poll '" + pollId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return pollResponse.Poll, nil
+}
+
+// Update an existing poll at LeanIX.
+func (leanix *LeanixClient) UpdatePoll(poll Poll) (*Poll, error) {
+	_, respBody, err := leanix.doJSONRequest("PUT", "/services/poll/v2/polls/"+*poll.Id, poll)
+	if err != nil {
+		return nil, err
+	}
+
+	pollResponse := PollResponse{}
+	err = json.Unmarshal(respBody, &pollResponse)
+	if err != nil {
+		return nil, err
+	}
+	if pollResponse.Poll == nil || pollResponse.Poll.Id == nil {
+		return nil, errors.New("Failed to update poll '" + poll.Title + "'. Maybe it was deleted outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return pollResponse.Poll, nil
+}
+
+// Delete a poll at LeanIX.
+func (leanix *LeanixClient) DeletePoll(pollId string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/poll/v2/polls/"+pollId, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete poll '" + pollId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Start a new run of a poll at LeanIX, which sends the poll to its recipients.
+func (leanix *LeanixClient) CreatePollRun(pollRun PollRun) (*PollRun, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/poll/v2/polls/"+pollRun.PollId+"/pollRuns", pollRun)
+	if err != nil {
+		return nil, err
+	}
+
+	pollRunResponse := PollRunResponse{}
+	err = json.Unmarshal(respBody, &pollRunResponse)
+	if err != nil {
+		return nil, err
+	}
+	if pollRunResponse.PollRun == nil || pollRunResponse.PollRun.Id == nil {
+		return nil, errors.New("Failed to start run '" + pollRun.Title + "' of poll '" + pollRun.PollId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return pollRunResponse.PollRun, nil
+}
+
+// Read a poll run from LeanIX.
+// Returns nil if the poll run does not exist.
+func (leanix *LeanixClient) ReadPollRun(pollRunId string) (*PollRun, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/poll/v2/pollRuns/"+pollRunId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	pollRunResponse := PollRunResponse{}
+	err = json.Unmarshal(respBody, &pollRunResponse)
+	if err != nil {
+		return nil, err
+	}
+	if pollRunResponse.PollRun == nil || pollRunResponse.PollRun.Id == nil {
+		return nil, errors.New("Failed to read poll run '" + pollRunId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return pollRunResponse.PollRun, nil
+}
+
+// Update the title or due date of a poll run at LeanIX.
+func (leanix *LeanixClient) UpdatePollRun(pollRun PollRun) (*PollRun, error) {
+	_, respBody, err := leanix.doJSONRequest("PUT", "/services/poll/v2/pollRuns/"+*pollRun.Id, pollRun)
+	if err != nil {
+		return nil, err
+	}
+
+	pollRunResponse := PollRunResponse{}
+	err = json.Unmarshal(respBody, &pollRunResponse)
+	if err != nil {
+		return nil, err
+	}
+	if pollRunResponse.PollRun == nil || pollRunResponse.PollRun.Id == nil {
+		return nil, errors.New("Failed to update poll run '" + *pollRun.Id + "'. Maybe it was deleted outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return pollRunResponse.PollRun, nil
+}
+
+// Delete a poll run including its results at LeanIX.
+func (leanix *LeanixClient) DeletePollRun(pollRunId string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/poll/v2/pollRuns/"+pollRunId, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete poll run '" + pollRunId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
+}
+
+// Create a new automation at LeanIX.
+func (leanix *LeanixClient) CreateAutomation(automation Automation) (*Automation, error) {
+	_, respBody, err := leanix.doJSONRequest("POST", "/services/automations/v1/automations", automation)
+	if err != nil {
+		return nil, err
+	}
+
+	automationResponse := AutomationResponse{}
+	err = json.Unmarshal(respBody, &automationResponse)
+	if err != nil {
+		return nil, err
+	}
+	if automationResponse.Automation == nil || automationResponse.Automation.Id == nil {
+		return nil, errors.New("Failed to create automation '" + automation.Name + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return automationResponse.Automation, nil
+}
+
+// Read an automation from LeanIX.
+// Returns nil if the automation does not exist.
+func (leanix *LeanixClient) ReadAutomation(automationId string) (*Automation, error) {
+	status, respBody, err := leanix.doJSONRequest("GET", "/services/automations/v1/automations/"+automationId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+
+	automationResponse := AutomationResponse{}
+	err = json.Unmarshal(respBody, &automationResponse)
+	if err != nil {
+		return nil, err
+	}
+	if automationResponse.Automation == nil || automationResponse.Automation.Id == nil {
+		return nil, errors.New("Failed to read automation '" + automationId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return automationResponse.Automation, nil
+}
+
+// Update an existing automation at LeanIX.
+func (leanix *LeanixClient) UpdateAutomation(automation Automation) (*Automation, error) {
+	_, respBody, err := leanix.doJSONRequest("PUT", "/services/automations/v1/automations/"+*automation.Id, automation)
+	if err != nil {
+		return nil, err
+	}
+
+	automationResponse := AutomationResponse{}
+	err = json.Unmarshal(respBody, &automationResponse)
+	if err != nil {
+		return nil, err
+	}
+	if automationResponse.Automation == nil || automationResponse.Automation.Id == nil {
+		return nil, errors.New("Failed to update automation '" + automation.Name + "'. Maybe it was deleted outside of Terraform? Here's the response from LeanIX: " + string(respBody))
+	}
+	return automationResponse.Automation, nil
+}
+
+// Delete an automation at LeanIX.
+func (leanix *LeanixClient) DeleteAutomation(automationId string) error {
+	status, respBody, err := leanix.doJSONRequest("DELETE", "/services/automations/v1/automations/"+automationId, nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK && status != http.StatusNoContent && status != http.StatusNotFound {
+		return errors.New("Failed to delete automation '" + automationId + "'. Here's the response from LeanIX: " + string(respBody))
+	}
+	return nil
 }
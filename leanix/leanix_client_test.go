@@ -1,11 +1,20 @@
 package leanix
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 )
 
 func apiTokenAndLeanixBasicAuthHeader() (string, string) {
@@ -306,6 +315,1467 @@ func TestDeleteWebhookSubscription(t *testing.T) {
 	assertEqual(t, subscriptionResponse, subscription)
 }
 
+func TestAddFactSheetTag(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	factSheetId := "28fe4aa2-6e46-41a1-a131-72afb3acf256"
+	tagId := "c3e2b2a4-9b6e-4d8b-8fba-1d5c2a7e8f10"
+	expectedBody, err := json.Marshal(GraphQLRequest{
+		Query: updateFactSheetMutation,
+		Variables: map[string]interface{}{
+			"id": factSheetId,
+			"patches": []FactSheetPatch{
+				{Op: "add", Path: "/tags", Value: "[{\"tagId\":\"" + tagId + "\"}]"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	factSheet := &FactSheet{
+		Id:   factSheetId,
+		Rev:  2,
+		Name: "Checkout",
+		Type: "Application",
+		Tags: []FactSheetTag{{Id: tagId, Name: "Team Payments"}},
+	}
+
+	graphQLRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			data, err := json.Marshal(map[string]interface{}{
+				"updateFactSheet": map[string]interface{}{"factSheet": factSheet},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			responseMarshal, err := json.Marshal(&GraphQLResponse{Data: data})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:   authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/graphql", Method: "POST"}: graphQLRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	factSheetResponse, err := client.AddFactSheetTag(factSheetId, tagId)
+	if err != nil {
+		t.Fatalf("LeanixClient.AddFactSheetTag() returned an error: %s", err)
+	}
+	assertEqual(t, factSheetResponse, factSheet)
+}
+
+func TestExecuteGraphQLReturnsErrors(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, _ := NewAuthRouteDefinition(t, apiToken)
+
+	expectedBody, err := json.Marshal(GraphQLRequest{Query: readFactSheetQuery, Variables: map[string]interface{}{"id": "unknown"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphQLRoute := &TestRouteDefinition{
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"data":{"factSheet":null},"errors":[{"message":"Access denied"},{"message":"Not found"}]}`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:   authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/graphql", Method: "POST"}: graphQLRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	_, err = client.ReadFactSheet("unknown")
+	if err == nil {
+		t.Fatal("LeanixClient.ReadFactSheet() should return an error")
+	}
+	assertEqual(t, err.Error(), "LeanIX GraphQL request failed: Access denied; Not found")
+}
+
+func TestCreateTechnicalUser(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	technicalUser := &TechnicalUser{
+		UserName:    "ci-integration",
+		Description: "Used by our CI pipeline",
+		Role:        "MEMBER",
+		WorkspaceId: "8751abbf-8093-410d-a090-10c7735952cf",
+		Expiry:      "2030-01-01T00:00:00Z",
+	}
+	technicalUserId := "id"
+	expectedBody, err := json.Marshal(*technicalUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			technicalUserWithToken := *technicalUser
+			technicalUserWithToken.Id = &technicalUserId
+			technicalUserWithToken.ApiToken = "generated_token"
+			responseMarshal, err := json.Marshal(&TechnicalUserResponse{
+				Status:        "OK",
+				TechnicalUser: &technicalUserWithToken,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:   authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/technicalUsers", Method: "POST"}: createRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	technicalUserResponse, err := client.CreateTechnicalUser(*technicalUser)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateTechnicalUser() returned an error: %s", err)
+	}
+	assertEqual(t, technicalUserResponse.Id, &technicalUserId)
+	assertEqual(t, technicalUserResponse.ApiToken, "generated_token")
+}
+
+func TestReadTechnicalUserNotFound(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	getRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusNotFound
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"status":"ERROR","errors":[{"value":"Technical user not found"}]}`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:     authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/technicalUsers/id", Method: "GET"}: getRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	technicalUserResponse, err := client.ReadTechnicalUser("id")
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadTechnicalUser() returned an error: %s", err)
+	}
+	if technicalUserResponse != nil {
+		t.Fatalf("Expected no technical user but got %v", technicalUserResponse)
+	}
+}
+
+func TestReplaceTechnicalUserApiToken(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	technicalUserId := "id"
+	replaceRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			responseMarshal, err := json.Marshal(&TechnicalUserResponse{
+				Status: "OK",
+				TechnicalUser: &TechnicalUser{
+					Id:       &technicalUserId,
+					UserName: "ci-integration",
+					Role:     "MEMBER",
+					ApiToken: "rotated_token",
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                                           authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/technicalUsers/" + technicalUserId + "/replaceApiToken", Method: "POST"}: replaceRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	technicalUserResponse, err := client.ReplaceTechnicalUserApiToken(technicalUserId)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReplaceTechnicalUserApiToken() returned an error: %s", err)
+	}
+	assertEqual(t, technicalUserResponse.ApiToken, "rotated_token")
+}
+
+func TestInviteWorkspaceUser(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	workspaceId := "8751abbf-8093-410d-a090-10c7735952cf"
+	invitation := WorkspaceInvitation{
+		User:       WorkspaceUser{Email: "jane.doe@example.com"},
+		Permission: WorkspacePermission{WorkspaceId: workspaceId, Role: "VIEWER"},
+		Message:    "Welcome!",
+	}
+	expectedBody, err := json.Marshal(invitation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	archivedPermissionId := "archived"
+	permissionId := "id"
+	permission := WorkspacePermission{
+		Id:          &permissionId,
+		WorkspaceId: workspaceId,
+		User:        WorkspaceUser{Id: "user", Email: "Jane.Doe@example.com"},
+		Role:        "VIEWER",
+		Status:      "INVITED",
+	}
+
+	inviteRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"status":"OK"}`)
+		},
+	}
+	listRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			responseMarshal, err := json.Marshal(&WorkspacePermissionListResponse{
+				Status: "OK",
+				Permissions: []WorkspacePermission{
+					{Id: &archivedPermissionId, WorkspaceId: workspaceId, User: permission.User, Role: "ADMIN", Status: "ARCHIVED"},
+					permission,
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                              authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/idm/invite", Method: "POST"}:                                inviteRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/workspaces/" + workspaceId + "/permissions", Method: "GET"}: listRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	permissionResponse, err := client.InviteWorkspaceUser(invitation)
+	if err != nil {
+		t.Fatalf("LeanixClient.InviteWorkspaceUser() returned an error: %s", err)
+	}
+	assertEqual(t, permissionResponse, &permission)
+}
+
+func TestListWorkspacePermissions(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	workspaceId := "8751abbf-8093-410d-a090-10c7735952cf"
+	var permissions []WorkspacePermission
+	for i := 0; i < mtmPageSize+1; i++ {
+		permissionId := fmt.Sprintf("permission-%d", i)
+		permissions = append(permissions, WorkspacePermission{
+			Id:          &permissionId,
+			WorkspaceId: workspaceId,
+			User:        WorkspaceUser{Id: fmt.Sprintf("user-%d", i), Email: fmt.Sprintf("user-%d@example.com", i)},
+			Role:        "VIEWER",
+			Status:      "ACTIVE",
+		})
+	}
+
+	pageRequests := 0
+	listRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			page := permissions[:mtmPageSize]
+			if pageRequests > 0 {
+				page = permissions[mtmPageSize:]
+			}
+			pageRequests++
+			responseMarshal, err := json.Marshal(&WorkspacePermissionListResponse{
+				Status:      "OK",
+				Total:       len(permissions),
+				Permissions: page,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                              authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/workspaces/" + workspaceId + "/permissions", Method: "GET"}: listRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	permissionsResponse, err := client.ListWorkspacePermissions(workspaceId, "")
+	if err != nil {
+		t.Fatalf("LeanixClient.ListWorkspacePermissions() returned an error: %s", err)
+	}
+	assertEqual(t, pageRequests, 2)
+	assertEqual(t, permissionsResponse, permissions)
+}
+
+func TestReadWorkspacePermission(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	permissionId := "id"
+	permission := &WorkspacePermission{
+		Id:          &permissionId,
+		WorkspaceId: "8751abbf-8093-410d-a090-10c7735952cf",
+		User:        WorkspaceUser{Id: "user", Email: "jane.doe@example.com"},
+		Role:        "ADMIN",
+		Status:      "ACTIVE",
+	}
+	readRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			responseMarshal, err := json.Marshal(&WorkspacePermissionResponse{Status: "OK", Permission: permission})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+	missingRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusNotFound
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"status":"ERROR"}`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:               authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/permissions/" + permissionId, Method: "GET"}: readRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/permissions/missing", Method: "GET"}:         missingRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	permissionResponse, err := client.ReadWorkspacePermission(permissionId)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWorkspacePermission() returned an error: %s", err)
+	}
+	assertEqual(t, permissionResponse, permission)
+
+	permissionResponse, err = client.ReadWorkspacePermission("missing")
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWorkspacePermission() returned an error: %s", err)
+	}
+	if permissionResponse != nil {
+		t.Fatalf("Expected no permission for a missing permission but got %v", permissionResponse)
+	}
+}
+
+func TestUpsertProcessorConfiguration(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	configuration := &ProcessorConfiguration{
+		ProcessorConfigurationKey: ProcessorConfigurationKey{
+			ConnectorType:       "ee",
+			ConnectorId:         "Kub-Dev-001",
+			ConnectorVersion:    "1.2.0",
+			ProcessingDirection: "inbound",
+			ProcessingMode:      "partial",
+		},
+		Processors: json.RawMessage(`[{"processorName":"Apps","processorType":"inboundFactSheet"}]`),
+	}
+	expectedBody, err := json.Marshal(*configuration)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upsertRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return body
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:              authRoute,
+			TestResourceAndMethod{Resource: "/services/integration-api/v1/configurations", Method: "PUT"}: upsertRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	configurationResponse, err := client.UpsertProcessorConfiguration(*configuration)
+	if err != nil {
+		t.Fatalf("LeanixClient.UpsertProcessorConfiguration() returned an error: %s", err)
+	}
+	assertEqual(t, configurationResponse, configuration)
+}
+
+func TestStartSynchronizationRun(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	ldif := json.RawMessage(`{"connectorId":"Kub-Dev-001","connectorType":"ee","content":[]}`)
+	startRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: ldif,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"id":"run","status":"CREATED"}`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                    authRoute,
+			TestResourceAndMethod{Resource: "/services/integration-api/v1/synchronizationRuns", Method: "POST"}: startRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	run, err := client.StartSynchronizationRun(ldif)
+	if err != nil {
+		t.Fatalf("LeanixClient.StartSynchronizationRun() returned an error: %s", err)
+	}
+	assertEqual(t, run, &SynchronizationRun{Id: "run"})
+}
+
+func TestCreateMetricsSchema(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	metricsSchema := &MetricsSchema{
+		Name:        "deployments",
+		Description: "Deployments per application",
+		Attributes: []MetricsSchemaAttribute{
+			{Name: "factSheetId", Type: "factSheetId"},
+			{Name: "count", Type: "metric"},
+		},
+	}
+	schemaId := "id"
+	expectedBody, err := json.Marshal(*metricsSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			schemaWithId := *metricsSchema
+			schemaWithId.Id = &schemaId
+			responseMarshal, err := json.Marshal(schemaWithId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/metrics/v2/schemas", Method: "POST"}:  createRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	schemaResponse, err := client.CreateMetricsSchema(*metricsSchema)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateMetricsSchema() returned an error: %s", err)
+	}
+	assertEqual(t, schemaResponse.Id, &schemaId)
+	schemaResponse.Id = nil // we remove the ID and check if the rest of the struct is also equal
+	assertEqual(t, schemaResponse, metricsSchema)
+}
+
+func TestQueryMetricsPoints(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	schemaId := "id"
+	queryRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`[{"timestamp":"2024-01-01T00:00:00Z","factSheetId":"fs","count":3}]`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                       authRoute,
+			TestResourceAndMethod{Resource: "/services/metrics/v2/schemas/" + schemaId + "/points", Method: "GET"}: queryRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	points, err := client.QueryMetricsPoints(schemaId, "2024-01-01T00:00:00Z", "2024-01-31T00:00:00Z", "fs")
+	if err != nil {
+		t.Fatalf("LeanixClient.QueryMetricsPoints() returned an error: %s", err)
+	}
+	assertEqual(t, points, []MetricsPoint{
+		{"timestamp": "2024-01-01T00:00:00Z", "factSheetId": "fs", "count": float64(3)},
+	})
+}
+
+func TestReadFactSheetSubscriptions(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	factSheetId := "28fe4aa2-6e46-41a1-a131-72afb3acf256"
+	expectedBody, err := json.Marshal(GraphQLRequest{Query: readFactSheetSubscriptionsQuery, Variables: map[string]interface{}{"id": factSheetId}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphQLRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"data":{"factSheet":{"subscriptions":{"edges":[{"node":{
+  "id":"sub","type":"RESPONSIBLE",
+  "user":{"id":"user","email":"jane.doe@example.com"},
+  "roles":[{"id":"role","name":"Business Owner"}]
+}}]}}}}`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:   authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/graphql", Method: "POST"}: graphQLRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	subscriptions, err := client.ReadFactSheetSubscriptions(factSheetId)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadFactSheetSubscriptions() returned an error: %s", err)
+	}
+	assertEqual(t, subscriptions, []FactSheetSubscription{
+		{
+			Id:    "sub",
+			Type:  "RESPONSIBLE",
+			User:  FactSheetSubscriber{Id: "user", Email: "jane.doe@example.com"},
+			Roles: []FactSheetSubscriptionRole{{Id: "role", Name: "Business Owner"}},
+		},
+	})
+}
+
+func TestCreateFactSheetDocument(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	factSheetId := "28fe4aa2-6e46-41a1-a131-72afb3acf256"
+	document := FactSheetDocument{
+		Name:         "Runbook",
+		Url:          "https://wiki.example.com/checkout/runbook",
+		Description:  "What to do when it breaks",
+		DocumentType: "documentation",
+	}
+	expectedBody, err := json.Marshal(GraphQLRequest{
+		Query: createFactSheetDocumentMutation,
+		Variables: map[string]interface{}{
+			"factSheetId":  factSheetId,
+			"name":         document.Name,
+			"url":          document.Url,
+			"description":  document.Description,
+			"documentType": document.DocumentType,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphQLRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			documentWithId := document
+			documentWithId.Id = "doc"
+			data, err := json.Marshal(map[string]interface{}{"createDocument": documentWithId})
+			if err != nil {
+				t.Fatal(err)
+			}
+			responseMarshal, err := json.Marshal(&GraphQLResponse{Data: data})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:   authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/graphql", Method: "POST"}: graphQLRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	documentResponse, err := client.CreateFactSheetDocument(factSheetId, document)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateFactSheetDocument() returned an error: %s", err)
+	}
+	assertEqual(t, documentResponse.Id, "doc")
+	documentResponse.Id = "" // we remove the ID and check if the rest of the struct is also equal
+	assertEqual(t, *documentResponse, document)
+}
+
+func TestUploadCustomReport(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	bundle := []byte("PK\x03\x04 zipped report")
+	uploadRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{"Authorization": authHeader},
+		MatchBody: func(header http.Header, body []byte) error {
+			mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+			if err != nil {
+				return err
+			}
+			if mediaType != "multipart/form-data" {
+				return fmt.Errorf("expected a multipart form but got '%s'", mediaType)
+			}
+			form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(1 << 20)
+			if err != nil {
+				return err
+			}
+			if len(form.Value) > 0 || len(form.File) != 1 || len(form.File["file"]) != 1 {
+				return fmt.Errorf("expected only the file field but got the fields %v and files %v", form.Value, form.File)
+			}
+			fileHeader := form.File["file"][0]
+			if fileHeader.Filename != "bundle.zip" {
+				return fmt.Errorf("expected the file name 'bundle.zip' but got '%s'", fileHeader.Filename)
+			}
+			file, err := fileHeader.Open()
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			content, err := ioutil.ReadAll(file)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(content, bundle) {
+				return fmt.Errorf("expected the bundle as file content but got %q", content)
+			}
+			return nil
+		},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			return []byte(`{"status":"OK","data":{"id":"report","reportId":"net.example.report","version":"1.2.0","title":"Example","enabled":true}}`)
+		},
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:          authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/reports/upload", Method: "POST"}: uploadRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	report, err := client.UploadCustomReport(bundle)
+	if err != nil {
+		t.Fatalf("LeanixClient.UploadCustomReport() returned an error: %s", err)
+	}
+	assertEqual(t, report, &CustomReport{Id: "report", ReportId: "net.example.report", Version: "1.2.0", Title: "Example", Enabled: true})
+}
+
+func TestCreateBookmark(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	bookmark := Bookmark{
+		Name:        "All Applications",
+		Description: "Every application in the workspace",
+		Type:        "INVENTORY",
+		Sharing:     "SHARED",
+		State:       json.RawMessage(`{"filters":{"facetFilter":[]}}`),
+	}
+	expectedBody, err := json.Marshal(bookmark)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bookmarkRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			bookmarkWithId := bookmark
+			id := "bookmark"
+			bookmarkWithId.Id = &id
+			responseMarshal, err := json.Marshal(&BookmarkResponse{Status: "OK", Bookmark: &bookmarkWithId})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:     authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/bookmarks", Method: "POST"}: bookmarkRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	bookmarkResponse, err := client.CreateBookmark(bookmark)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateBookmark() returned an error: %s", err)
+	}
+	assertEqual(t, *bookmarkResponse.Id, "bookmark")
+	bookmarkResponse.Id = nil // we remove the ID and check if the rest of the struct is also equal
+	assertEqual(t, *bookmarkResponse, bookmark)
+}
+
+func TestCreatePollRun(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	pollRun := PollRun{
+		PollId:  "3f1b5a62-1d2e-4f1c-9b55-0f4a3c2d7e11",
+		Title:   "Application Owner Survey 2026",
+		DueDate: "2026-12-31",
+	}
+	expectedBody, err := json.Marshal(pollRun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pollRunRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			pollRunWithId := pollRun
+			id := "run"
+			pollRunWithId.Id = &id
+			pollRunWithId.Status = "ACTIVE"
+			responseMarshal, err := json.Marshal(&PollRunResponse{Status: "OK", PollRun: &pollRunWithId})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                           authRoute,
+			TestResourceAndMethod{Resource: "/services/poll/v2/polls/" + pollRun.PollId + "/pollRuns", Method: "POST"}: pollRunRoute,
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	pollRunResponse, err := client.CreatePollRun(pollRun)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreatePollRun() returned an error: %s", err)
+	}
+	assertEqual(t, *pollRunResponse.Id, "run")
+	assertEqual(t, pollRunResponse.Status, "ACTIVE")
+	pollRunResponse.Id = nil // we remove the computed fields and check if the rest of the struct is also equal
+	pollRunResponse.Status = ""
+	assertEqual(t, *pollRunResponse, pollRun)
+}
+
+func newTestAutomation() Automation {
+	return Automation{
+		Name:       "Notify owners",
+		Enabled:    true,
+		Trigger:    json.RawMessage(`{"type":"FACT_SHEET_CREATED","factSheetType":"Application"}`),
+		Conditions: json.RawMessage(`[]`),
+		Actions:    json.RawMessage(`[{"type":"SEND_EMAIL","recipients":["owner"]}]`),
+	}
+}
+
+func newAutomationRoute(t *testing.T, authHeader string, expectedBody []byte, status int, response *AutomationResponse) *TestRouteDefinition {
+	expectedHeader := map[string]string{"Authorization": authHeader}
+	if len(expectedBody) > 0 {
+		expectedHeader["Content-Type"] = "application/json"
+	}
+	return &TestRouteDefinition{
+		ExpectedHeader: expectedHeader,
+		ExpectedBody:   expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return status
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			if response == nil {
+				return []byte{}
+			}
+			responseMarshal, err := json.Marshal(response)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+}
+
+func TestCreateAutomation(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	automation := newTestAutomation()
+	expectedBody, err := json.Marshal(automation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := "automation"
+	automationWithId := automation
+	automationWithId.Id = &id
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:        authRoute,
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations", Method: "POST"}: newAutomationRoute(t, authHeader, expectedBody, http.StatusOK, &AutomationResponse{Status: "OK", Automation: &automationWithId}),
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	automationResponse, err := client.CreateAutomation(automation)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateAutomation() returned an error: %s", err)
+	}
+	assertEqual(t, *automationResponse, automationWithId)
+}
+
+func TestReadAutomation(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	id := "automation"
+	automation := newTestAutomation()
+	automation.Id = &id
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                  authRoute,
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/automation", Method: "GET"}: newAutomationRoute(t, authHeader, []byte{}, http.StatusOK, &AutomationResponse{Status: "OK", Automation: &automation}),
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/missing", Method: "GET"}:    newAutomationRoute(t, authHeader, []byte{}, http.StatusNotFound, nil),
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	automationResponse, err := client.ReadAutomation(id)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadAutomation() returned an error: %s", err)
+	}
+	assertEqual(t, *automationResponse, automation)
+
+	automationResponse, err = client.ReadAutomation("missing")
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadAutomation() returned an error: %s", err)
+	}
+	if automationResponse != nil {
+		t.Fatalf("Expected no automation, but got %v", automationResponse)
+	}
+}
+
+func TestUpdateAutomation(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	id := "automation"
+	automation := newTestAutomation()
+	automation.Id = &id
+	automation.Enabled = false
+	expectedBody, err := json.Marshal(automation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	missingId := "missing"
+	missing := automation
+	missing.Id = &missingId
+	missingBody, err := json.Marshal(missing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                  authRoute,
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/automation", Method: "PUT"}: newAutomationRoute(t, authHeader, expectedBody, http.StatusOK, &AutomationResponse{Status: "OK", Automation: &automation}),
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/missing", Method: "PUT"}:    newAutomationRoute(t, authHeader, missingBody, http.StatusNotFound, &AutomationResponse{Status: "ERROR"}),
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	automationResponse, err := client.UpdateAutomation(automation)
+	if err != nil {
+		t.Fatalf("LeanixClient.UpdateAutomation() returned an error: %s", err)
+	}
+	assertEqual(t, *automationResponse, automation)
+
+	_, err = client.UpdateAutomation(missing)
+	if err == nil || !strings.Contains(err.Error(), "Maybe it was deleted outside of Terraform?") {
+		t.Fatalf("Expected an error for a missing automation, but got %v", err)
+	}
+}
+
+func TestDeleteAutomation(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                     authRoute,
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/automation", Method: "DELETE"}: newAutomationRoute(t, authHeader, []byte{}, http.StatusNoContent, nil),
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/missing", Method: "DELETE"}:    newAutomationRoute(t, authHeader, []byte{}, http.StatusNotFound, nil),
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/locked", Method: "DELETE"}:     newAutomationRoute(t, authHeader, []byte{}, http.StatusForbidden, &AutomationResponse{Status: "ERROR"}),
+		},
+	)
+
+	defer testServer.Close()
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	if err := client.DeleteAutomation("automation"); err != nil {
+		t.Fatalf("LeanixClient.DeleteAutomation() returned an error: %s", err)
+	}
+	// an automation which is gone already counts as deleted
+	if err := client.DeleteAutomation("missing"); err != nil {
+		t.Fatalf("LeanixClient.DeleteAutomation() returned an error: %s", err)
+	}
+	err := client.DeleteAutomation("locked")
+	if err == nil || !strings.Contains(err.Error(), "Failed to delete automation 'locked'") {
+		t.Fatalf("Expected an error for a forbidden delete, but got %v", err)
+	}
+}
+
+func TestWebhookSubscriptionCassette(t *testing.T) {
+	client := NewCassetteClient(t, "webhook_subscription")
+
+	subscription := WebhookSubscription{
+		Identifier:          "cassette-test",
+		DeliveryType:        "PUSH",
+		TagSets:             [][]string{{"pathfinder", "FACT_SHEET_UPDATED"}},
+		TargetUrl:           "https://example.com/hook",
+		TargetMethod:        "POST",
+		AuthorizationHeader: "Basic dXNlcjpwYXNz",
+		WorkspaceConstraint: "ANY",
+		PayloadMode:         "WRAPPED_EVENT",
+		Active:              true,
+	}
+	created, err := client.CreateWebhookSubscription(subscription)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateWebhookSubscription() returned an error: %s", err)
+	}
+	read, err := client.ReadWebhookSubscription(*created.Id)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWebhookSubscription() returned an error: %s", err)
+	}
+	assertEqual(t, read.Identifier, subscription.Identifier)
+	assertEqual(t, read.TagSets, subscription.TagSets)
+
+	_, err = client.DeleteWebhookSubscription(*created.Id)
+	if err != nil {
+		t.Fatalf("LeanixClient.DeleteWebhookSubscription() returned an error: %s", err)
+	}
+	_, err = client.ReadWebhookSubscription(*created.Id)
+	if err == nil || !strings.Contains(err.Error(), "No such subscription") {
+		t.Fatalf("Expected the deleted subscription to be gone, got: %v", err)
+	}
+}
+
+// Create a client for a test server serving a webhook subscription with the
+// given faults. The returned counters hold the number of token and
+// subscription requests which reached the server.
+func newFaultyWebhookSubscriptionClient(t *testing.T, faults TestFaults) (*LeanixClient, *WebhookSubscription, *int, *int) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	subscriptionId := "id"
+	subscription := &WebhookSubscription{
+		Id:         &subscriptionId,
+		Identifier: "hook",
+		TagSets:    [][]string{{"pathfinder", "FACT_SHEET_CREATED"}},
+	}
+
+	tokenRequests := 0
+	authResponseBody := authRoute.ResponseBody
+	authRoute.ResponseBody = func(header http.Header, body []byte) []byte {
+		tokenRequests++
+		return authResponseBody(header, body)
+	}
+	subscriptionRequests := 0
+	subscriptionRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: []byte{},
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			subscriptionRequests++
+			responseMarshal, err := json.Marshal(&WebhookSubscriptionResponse{Status: "OK", Subscription: subscription})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewFaultyTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                        authRoute,
+			TestResourceAndMethod{Resource: "/services/webhooks/v1/subscriptions/" + subscriptionId, Method: "GET"}: subscriptionRoute,
+		},
+		faults,
+	)
+	t.Cleanup(testServer.Close)
+
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	client.retryWaitMin = time.Millisecond
+	client.retryWaitMax = 10 * time.Millisecond
+	return client, subscription, &tokenRequests, &subscriptionRequests
+}
+
+func TestRetryOnTooManyRequests(t *testing.T) {
+	client, subscription, _, subscriptionRequests := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		FailureStatus: http.StatusTooManyRequests,
+		FailureCount:  2,
+		RetryAfter:    "1",
+	})
+
+	start := time.Now()
+	subscriptionResponse, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWebhookSubscription() returned an error: %s", err)
+	}
+	assertEqual(t, subscriptionResponse, subscription)
+	assertEqual(t, *subscriptionRequests, 1)
+	if time.Since(start) > time.Second {
+		t.Fatal("Expected Retry-After to be capped by the maximum wait")
+	}
+}
+
+func TestGiveUpAfterMaxRetries(t *testing.T) {
+	client, subscription, _, subscriptionRequests := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		FailureStatus: http.StatusServiceUnavailable,
+		FailureCount:  maxRetries + 1,
+	})
+
+	_, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err == nil {
+		t.Fatal("Expected LeanixClient.ReadWebhookSubscription() to fail while LeanIX is unavailable")
+	}
+	assertEqual(t, *subscriptionRequests, 0)
+}
+
+// Create a client for a test server creating a webhook subscription with the
+// given faults.
+func newFaultyWebhookSubscriptionCreateClient(t *testing.T, faults TestFaults) (*LeanixClient, WebhookSubscription) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+
+	subscription := WebhookSubscription{
+		Identifier: "hook",
+		TagSets:    [][]string{{"pathfinder", "FACT_SHEET_CREATED"}},
+	}
+	expectedBody, err := json.Marshal(subscription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscriptionRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			subscriptionId := "id"
+			created := subscription
+			created.Id = &subscriptionId
+			responseMarshal, err := json.Marshal(&WebhookSubscriptionResponse{Status: "OK", Subscription: &created})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+
+	testServer := NewFaultyTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:       authRoute,
+			TestResourceAndMethod{Resource: "/services/webhooks/v1/subscriptions", Method: "POST"}: subscriptionRoute,
+		},
+		faults,
+	)
+	t.Cleanup(testServer.Close)
+
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	client.retryWaitMin = time.Millisecond
+	client.retryWaitMax = 10 * time.Millisecond
+	return client, subscription
+}
+
+func TestRetryCreateOnlyIfUnprocessed(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, http.StatusServiceUnavailable} {
+		client, subscription := newFaultyWebhookSubscriptionCreateClient(t, TestFaults{
+			FailureStatus: status,
+			FailureCount:  1,
+		})
+		if _, err := client.CreateWebhookSubscription(subscription); err != nil {
+			t.Errorf("Expected a create rejected with %d to be retried, but got: %s", status, err)
+		}
+	}
+
+	// LeanIX may have created the subscription before the gateway failed
+	for _, status := range []int{http.StatusBadGateway, http.StatusGatewayTimeout} {
+		client, subscription := newFaultyWebhookSubscriptionCreateClient(t, TestFaults{
+			FailureStatus: status,
+			FailureCount:  1,
+		})
+		if _, err := client.CreateWebhookSubscription(subscription); err == nil {
+			t.Errorf("Expected a create failing with %d not to be retried", status)
+		}
+	}
+}
+
+func TestRetryWaitEndsWithContext(t *testing.T) {
+	client, subscription, _, _ := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		FailureStatus: http.StatusServiceUnavailable,
+		FailureCount:  1,
+	})
+	client.retryWaitMin = time.Minute
+	client.retryWaitMax = time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.withContext(ctx).ReadWebhookSubscription(*subscription.Id)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the retry to end with the context, but got: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("Expected the wait for the retry to end with the context")
+	}
+}
+
+func TestNoRetryOnServerError(t *testing.T) {
+	client, subscription, _, _ := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		FailureStatus: http.StatusInternalServerError,
+		FailureCount:  1,
+	})
+
+	_, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err == nil {
+		t.Fatal("Expected LeanixClient.ReadWebhookSubscription() to fail on an internal server error")
+	}
+}
+
+func TestRetryOnTruncatedResponse(t *testing.T) {
+	client, subscription, _, subscriptionRequests := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		TruncatedCount: 1,
+	})
+
+	subscriptionResponse, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWebhookSubscription() returned an error: %s", err)
+	}
+	assertEqual(t, subscriptionResponse, subscription)
+	assertEqual(t, *subscriptionRequests, 2)
+}
+
+func TestMalformedResponse(t *testing.T) {
+	client, subscription, _, _ := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		MalformedJSON: true,
+	})
+
+	_, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err == nil {
+		t.Fatal("Expected LeanixClient.ReadWebhookSubscription() to fail on malformed JSON")
+	}
+}
+
+func TestTimeoutOnLatency(t *testing.T) {
+	client, subscription, _, _ := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		Latency: 100 * time.Millisecond,
+	})
+	client.http.Timeout = 20 * time.Millisecond
+
+	_, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err == nil {
+		t.Fatal("Expected LeanixClient.ReadWebhookSubscription() to time out")
+	}
+}
+
+func TestRefreshExpiredToken(t *testing.T) {
+	client, subscription, tokenRequests, subscriptionRequests := newFaultyWebhookSubscriptionClient(t, TestFaults{
+		TokenExpiresAfter: 1,
+	})
+
+	for i := 0; i < 2; i++ {
+		subscriptionResponse, err := client.ReadWebhookSubscription(*subscription.Id)
+		if err != nil {
+			t.Fatalf("LeanixClient.ReadWebhookSubscription() returned an error: %s", err)
+		}
+		assertEqual(t, subscriptionResponse, subscription)
+	}
+	assertEqual(t, *tokenRequests, 2)
+	assertEqual(t, *subscriptionRequests, 2)
+}
+
+func TestRenewTokenBeforeExpiry(t *testing.T) {
+	client, subscription, tokenRequests, _ := newFaultyWebhookSubscriptionClient(t, TestFaults{})
+
+	_, err := client.ReadWebhookSubscription(*subscription.Id)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWebhookSubscription() returned an error: %s", err)
+	}
+	client.authorizationTokenExpiry = time.Now().Add(-time.Second)
+	_, err = client.ReadWebhookSubscription(*subscription.Id)
+	if err != nil {
+		t.Fatalf("LeanixClient.ReadWebhookSubscription() returned an error: %s", err)
+	}
+	assertEqual(t, *tokenRequests, 2)
+}
+
 func NewAuthRouteDefinition(t *testing.T, apiToken string) (*TestRouteDefinition, string) {
 	tokenType := "Bearer"
 	accessToken := "this_is_my_awesome_oauth_token"
@@ -0,0 +1,76 @@
+package leanix
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const defaultLeanixUrl = "https://svc.leanix.net"
+
+// Regions with their own LeanIX instance at https://<region>-svc.leanix.net.
+var leanixRegions = []string{"eu", "us", "de", "ch", "au", "ca"}
+
+func regionUrl(region string) string {
+	return "https://" + region + "-svc.leanix.net"
+}
+
+// Determine the base URL of the LeanIX services from the url or region
+// attribute. Without either the default URL is used.
+func resolveLeanixUrl(rawUrl string, region string, defaultUrl string) (string, error) {
+	if rawUrl != "" && region != "" {
+		return "", errors.New("Only one of url and region can be set, as the region determines the URL.")
+	}
+	if region != "" {
+		return regionUrl(region), nil
+	}
+	if rawUrl == "" {
+		return defaultUrl, nil
+	}
+	return normalizeLeanixUrl(rawUrl)
+}
+
+// Normalize the base URL of the LeanIX services, so the paths of the services
+// can be appended. The scheme defaults to https and a trailing slash is
+// removed. For leanix.net, URLs copied from the web application, like
+// https://eu.leanix.net/myWorkspace/dashboard, are turned into the URL of
+// the services of the same region.
+func normalizeLeanixUrl(rawUrl string) (string, error) {
+	rawUrl = strings.TrimSpace(rawUrl)
+	if !strings.Contains(rawUrl, "://") {
+		rawUrl = "https://" + rawUrl
+	}
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", errors.New("Invalid LeanIX url '" + rawUrl + "': " + err.Error())
+	}
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return "", errors.New("Invalid LeanIX url '" + rawUrl + "'. Expected something like https://eu-svc.leanix.net.")
+	}
+
+	host := strings.ToLower(parsed.Host)
+	path := strings.TrimRight(parsed.Path, "/")
+	if host == "leanix.net" || strings.HasSuffix(host, ".leanix.net") {
+		// the LeanIX services are always served from the root
+		path = ""
+		if servicesHost, ok := leanixServicesHost(host); ok {
+			host = servicesHost
+		}
+	}
+	return parsed.Scheme + "://" + host + path, nil
+}
+
+// Map the host of the LeanIX web application, i.e. app.leanix.net or
+// <region>.leanix.net of a known region, to the host of its services. Other
+// hosts are not known to be web application hosts and stay as they are.
+func leanixServicesHost(host string) (string, bool) {
+	if host == "app.leanix.net" {
+		return "svc.leanix.net", true
+	}
+	for _, region := range leanixRegions {
+		if host == region+".leanix.net" {
+			return region + "-svc.leanix.net", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,52 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestNormalizeLeanixUrl(t *testing.T) {
+	for rawUrl, expected := range map[string]string{
+		"https://eu-svc.leanix.net":                 "https://eu-svc.leanix.net",
+		"https://eu-svc.leanix.net/":                "https://eu-svc.leanix.net",
+		" eu-svc.leanix.net ":                       "https://eu-svc.leanix.net",
+		"https://EU-SVC.leanix.net":                 "https://eu-svc.leanix.net",
+		"https://eu.leanix.net/acme/dashboard":      "https://eu-svc.leanix.net",
+		"https://app.leanix.net/acme":               "https://svc.leanix.net",
+		"https://svc.leanix.net":                    "https://svc.leanix.net",
+		"https://us-svc.leanix.net/services/mtm/v1": "https://us-svc.leanix.net",
+		"http://localhost:8080/":                    "http://localhost:8080",
+		"https://proxy.example.com/leanix/":         "https://proxy.example.com/leanix",
+		"https://xy.leanix.net/":                    "https://xy.leanix.net",
+		"https://demo-eu.leanix.net/acme":           "https://demo-eu.leanix.net",
+	} {
+		normalized, err := normalizeLeanixUrl(rawUrl)
+		if err != nil {
+			t.Fatalf("normalizeLeanixUrl(%q) returned an error: %s", rawUrl, err)
+		}
+		assertEqual(t, normalized, expected)
+	}
+
+	for _, rawUrl := range []string{"ftp://eu-svc.leanix.net", "https://", "https://eu svc.leanix.net"} {
+		if _, err := normalizeLeanixUrl(rawUrl); err == nil {
+			t.Fatalf("Expected normalizeLeanixUrl(%q) to fail", rawUrl)
+		}
+	}
+}
+
+func TestResolveLeanixUrl(t *testing.T) {
+	resolved, err := resolveLeanixUrl("", "ch", defaultLeanixUrl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, resolved, "https://ch-svc.leanix.net")
+
+	resolved, err = resolveLeanixUrl("", "", "https://de-svc.leanix.net")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, resolved, "https://de-svc.leanix.net")
+
+	if _, err := resolveLeanixUrl("https://eu-svc.leanix.net", "us", defaultLeanixUrl); err == nil {
+		t.Fatal("Expected an error if url and region are both set")
+	}
+}
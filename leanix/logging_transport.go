@@ -0,0 +1,88 @@
+package leanix
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codecentric/terraform-provider-leanix/leanix/redact"
+	"github.com/hashicorp/terraform/helper/logging"
+)
+
+// Logs every request to LeanIX through the standard logger, which Terraform
+// shows depending on TF_LOG: method, URL, status and latency at DEBUG,
+// headers and bodies at TRACE. Secrets are redacted.
+type loggingTransport struct {
+	transport http.RoundTripper
+	// Reading the bodies is only worth it if Terraform shows logs at all.
+	logBodies bool
+}
+
+func newLoggingTransport(transport http.RoundTripper) *loggingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &loggingTransport{
+		transport: transport,
+		logBodies: os.Getenv(logging.EnvLog) != "",
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.logBodies && req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		log.Printf("[TRACE] LeanIX request %s %s\n%s\n%s", req.Method, req.URL, formatHeader(req.Header), formatBody(req.Header, body))
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("[DEBUG] LeanIX %s %s failed after %s: %s", req.Method, req.URL, latency, err)
+		return nil, err
+	}
+	log.Printf("[DEBUG] LeanIX %s %s: %s (%s)", req.Method, req.URL, resp.Status, latency)
+
+	if t.logBodies {
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		log.Printf("[TRACE] LeanIX response %s %s: %s\n%s\n%s", req.Method, req.URL, resp.Status, formatHeader(resp.Header), formatBody(resp.Header, body))
+	}
+	return resp, nil
+}
+
+func formatHeader(header http.Header) string {
+	redacted := redact.Header(header)
+	var lines []string
+	for name, values := range redacted {
+		lines = append(lines, name+": "+strings.Join(values, ", "))
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// Binary bodies like zipped custom reports are only logged with their size.
+func formatBody(header http.Header, body []byte) string {
+	contentType := header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if len(body) > 0 && mediaType != "" && !strings.HasPrefix(mediaType, "text/") && !strings.Contains(mediaType, "json") && mediaType != "application/x-www-form-urlencoded" {
+		return fmt.Sprintf("<%d bytes of %s>", len(body), mediaType)
+	}
+	return redact.Body(contentType, body)
+}
@@ -0,0 +1,94 @@
+package leanix
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoggingTransport(t *testing.T) {
+	var logOutput bytes.Buffer
+	log.SetOutput(&logOutput)
+	defer log.SetOutput(os.Stderr)
+	t.Setenv("TF_LOG", "TRACE")
+
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	subscriptionId := "id"
+	subscription := WebhookSubscription{
+		Identifier:          "hook",
+		AuthorizationHeader: "Basic c2VjcmV0LXRhcmdldA==",
+	}
+	expectedBody, err := json.Marshal(subscription)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscriptionRoute := &TestRouteDefinition{
+		ExpectedHeader: map[string]string{
+			"Authorization": authHeader,
+		},
+		ExpectedBody: expectedBody,
+		ResponseStatus: func(header http.Header, body []byte) int {
+			return http.StatusOK
+		},
+		ResponseBody: func(header http.Header, body []byte) []byte {
+			created := subscription
+			created.Id = &subscriptionId
+			responseMarshal, err := json.Marshal(&WebhookSubscriptionResponse{Status: "OK", Subscription: &created})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return responseMarshal
+		},
+	}
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:       authRoute,
+			TestResourceAndMethod{Resource: "/services/webhooks/v1/subscriptions", Method: "POST"}: subscriptionRoute,
+		},
+	)
+	defer testServer.Close()
+
+	client := NewLeanixClient(
+		testServer.URL,
+		leanixBasicAuthHeader,
+	)
+	created, err := client.CreateWebhookSubscription(subscription)
+	if err != nil {
+		t.Fatalf("LeanixClient.CreateWebhookSubscription() returned an error: %s", err)
+	}
+	// the response body must still be readable after it was logged
+	assertEqual(t, *created.Id, subscriptionId)
+
+	logged := logOutput.String()
+	for _, expected := range []string{
+		"[DEBUG] LeanIX POST " + testServer.URL + "/services/mtm/v1/oauth2/token: 200 OK",
+		"[DEBUG] LeanIX POST " + testServer.URL + "/services/webhooks/v1/subscriptions: 200 OK",
+		"[TRACE] LeanIX request POST " + testServer.URL + "/services/webhooks/v1/subscriptions",
+		`"identifier":"hook"`,
+		"Authorization: REDACTED",
+	} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("Expected the log to contain %q:\n%s", expected, logged)
+		}
+	}
+	for _, secret := range []string{apiToken, leanixBasicAuthHeader, "this_is_my_awesome_oauth_token", subscription.AuthorizationHeader} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected the secret %q to be redacted:\n%s", secret, logged)
+		}
+	}
+}
+
+func TestFormatBody(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "multipart/form-data; boundary=x")
+	assertEqual(t, formatBody(header, []byte("PK\x03\x04")), "<4 bytes of multipart/form-data>")
+
+	header.Set("Content-Type", "application/json;charset=UTF-8")
+	assertEqual(t, formatBody(header, []byte(`{"apiToken": "secret"}`)), `{"apiToken":"REDACTED"}`)
+}
@@ -0,0 +1,84 @@
+package leanix
+
+import (
+	"fmt"
+	"time"
+)
+
+type MetricsSchema struct {
+	Id          *string                  `json:"id,omitempty"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description,omitempty"`
+	Attributes  []MetricsSchemaAttribute `json:"attributes"`
+}
+
+type MetricsSchemaAttribute struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// A metrics point has a timestamp and one value per attribute of its schema,
+// so it is handled as generic JSON object.
+type MetricsPoint map[string]interface{}
+
+var metricsSchemaAttributeTypes = []string{"dimension", "metric", "factSheetId"}
+
+// Create a metrics point from a timestamp and the values of its attributes.
+func newMetricsPoint(timestamp string, dimensions map[string]interface{}, metrics map[string]interface{}) MetricsPoint {
+	point := MetricsPoint{"timestamp": timestamp}
+	for name, value := range dimensions {
+		point[name] = value
+	}
+	for name, value := range metrics {
+		point[name] = value
+	}
+	return point
+}
+
+func (point MetricsPoint) Timestamp() string {
+	timestamp, _ := point["timestamp"].(string)
+	return timestamp
+}
+
+// Split the values of a metrics point into dimensions and metrics according to
+// the attribute types of the schema. Fact sheet IDs count as dimensions.
+func (point MetricsPoint) split(schema *MetricsSchema) (map[string]interface{}, map[string]interface{}) {
+	dimensions := map[string]interface{}{}
+	metrics := map[string]interface{}{}
+	for _, attribute := range schema.Attributes {
+		value, ok := point[attribute.Name]
+		if !ok || value == nil {
+			continue
+		}
+		if attribute.Type == "metric" {
+			metrics[attribute.Name] = value
+		} else {
+			dimensions[attribute.Name] = fmt.Sprint(value)
+		}
+	}
+	return dimensions, metrics
+}
+
+// Check if two points have exactly the same dimension values.
+func sameMetricsDimensions(a map[string]interface{}, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		otherValue, ok := b[name]
+		if !ok || fmt.Sprint(value) != fmt.Sprint(otherValue) {
+			return false
+		}
+	}
+	return true
+}
+
+// Timestamps are compared by the instant they represent, LeanIX may format them differently.
+func sameMetricsTimestamp(a string, b string) bool {
+	timeA, errA := time.Parse(time.RFC3339, a)
+	timeB, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return timeA.Equal(timeB)
+}
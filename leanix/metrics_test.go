@@ -0,0 +1,80 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestMetricsPointSplit(t *testing.T) {
+	metricsSchema := &MetricsSchema{
+		Name: "deployments",
+		Attributes: []MetricsSchemaAttribute{
+			{Name: "factSheetId", Type: "factSheetId"},
+			{Name: "environment", Type: "dimension"},
+			{Name: "count", Type: "metric"},
+			{Name: "duration", Type: "metric"},
+		},
+	}
+	point := newMetricsPoint(
+		"2024-01-01T00:00:00Z",
+		map[string]interface{}{"factSheetId": "fs", "environment": "prod"},
+		map[string]interface{}{"count": float64(3)},
+	)
+
+	dimensions, metrics := point.split(metricsSchema)
+	assertEqual(t, dimensions, map[string]interface{}{"factSheetId": "fs", "environment": "prod"})
+	assertEqual(t, metrics, map[string]interface{}{"count": float64(3)})
+	assertEqual(t, point.Timestamp(), "2024-01-01T00:00:00Z")
+}
+
+func TestSameMetricsTimestamp(t *testing.T) {
+	if !sameMetricsTimestamp("2024-01-01T01:00:00+01:00", "2024-01-01T00:00:00Z") {
+		t.Fatal("Expected timestamps of the same instant to be the same")
+	}
+	if sameMetricsTimestamp("2024-01-01T00:00:01Z", "2024-01-01T00:00:00Z") {
+		t.Fatal("Expected timestamps of different instants to differ")
+	}
+}
+
+func TestParseMetricsPointId(t *testing.T) {
+	schemaId, timestamp, err := parseMetricsPointId("schema/2024-01-01T00:00:00Z/")
+	if err != nil {
+		t.Fatalf("parseMetricsPointId() returned an error: %s", err)
+	}
+	assertEqual(t, schemaId, "schema")
+	assertEqual(t, timestamp, "2024-01-01T00:00:00Z")
+
+	schemaId, timestamp, err = parseMetricsPointId("schema/2024-01-01T00:00:00Z/environment=prod%2Feu")
+	if err != nil {
+		t.Fatalf("parseMetricsPointId() returned an error: %s", err)
+	}
+	assertEqual(t, schemaId, "schema")
+	assertEqual(t, timestamp, "2024-01-01T00:00:00Z")
+
+	for _, invalidId := range []string{"", "schema", "schema/", "/2024-01-01T00:00:00Z/", "schema/2024-01-01T00:00:00Z"} {
+		if _, _, err := parseMetricsPointId(invalidId); err == nil {
+			t.Errorf("parseMetricsPointId(%q) should return an error", invalidId)
+		}
+	}
+}
+
+func TestMetricsPointId(t *testing.T) {
+	id := metricsPointId("schema", "2024-01-01T00:00:00Z", map[string]interface{}{"factSheetId": "fs", "environment": "prod/eu"})
+	assertEqual(t, id, "schema/2024-01-01T00:00:00Z/environment=prod%2Feu&factSheetId=fs")
+	assertEqual(t, metricsPointId("schema", "2024-01-01T00:00:00Z", map[string]interface{}{}), "schema/2024-01-01T00:00:00Z/")
+}
+
+func TestSameMetricsDimensions(t *testing.T) {
+	dimensions := map[string]interface{}{"factSheetId": "fs", "environment": "prod"}
+	if !sameMetricsDimensions(dimensions, map[string]interface{}{"environment": "prod", "factSheetId": "fs"}) {
+		t.Error("Expected the same dimensions to be the same")
+	}
+	for _, other := range []map[string]interface{}{
+		{"factSheetId": "fs"},
+		{"factSheetId": "fs", "environment": "test"},
+		{"factSheetId": "fs", "environment": "prod", "region": "eu"},
+	} {
+		if sameMetricsDimensions(dimensions, other) {
+			t.Errorf("Expected %v to differ from %v", other, dimensions)
+		}
+	}
+}
@@ -1,36 +1,178 @@
 package leanix
 
 import (
+	"errors"
+	"math"
+
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"url": &schema.Schema{
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("LEANIX_URL", "https://svc.leanix.net"),
-				Description: "LeanIX service URL.",
+				DefaultFunc: schema.EnvDefaultFunc("LEANIX_URL", nil),
+				Description: "LeanIX service URL. Defaults to " + defaultLeanixUrl + " unless a region is set.",
+			},
+			"region": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LEANIX_REGION", nil),
+				Description:  "Region of the LeanIX instance, which determines the service URL. Can't be combined with url.",
+				ValidateFunc: validation.StringInSlice(leanixRegions, false),
+			},
+			"skip_connectivity_check": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LEANIX_SKIP_CONNECTIVITY_CHECK", false),
+				Description: "Don't check that LeanIX can be reached with the configured credentials when the provider is configured.",
+			},
+			"requests_per_second": &schema.Schema{
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LEANIX_REQUESTS_PER_SECOND", 0),
+				Description:  "Maximum number of requests per second sent to LeanIX with the same credentials, shared by all parallel operations. 0 doesn't limit the rate.",
+				ValidateFunc: validation.FloatBetween(0, math.MaxFloat64),
+			},
+			"max_concurrent_requests": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LEANIX_MAX_CONCURRENT_REQUESTS", 0),
+				Description:  "Maximum number of requests in flight to LeanIX with the same credentials, shared by all parallel operations. 0 doesn't limit the concurrency.",
+				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"auth_header": &schema.Schema{
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LEANIX_AUTH_HEADER", nil),
-				Description: "The LeanIX authentication header based on API token or client secret required to authenticate with LeanIX. See https://dev.leanix.net/docs/authentication for details.",
+				Description: "The LeanIX authentication header based on API token or client secret required to authenticate with LeanIX. See https://dev.leanix.net/docs/authentication for details. Only optional if all resources select one of the workspaces.",
+			},
+			"workspace": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Additional workspaces, which resources can select by name with their workspace attribute.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"url": &schema.Schema{
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "LeanIX service URL of the workspace. Defaults to the URL of the provider.",
+						},
+						"region": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Region of the LeanIX instance of the workspace. Can't be combined with url.",
+							ValidateFunc: validation.StringInSlice(leanixRegions, false),
+						},
+						"auth_header": &schema.Schema{
+							Type:      schema.TypeString,
+							Required:  true,
+							Sensitive: true,
+						},
+					},
+				},
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"leanix_webhook_subscription": resourceLeanixWebhookSubscription(),
+			"leanix_webhook_subscription":                    resourceLeanixWebhookSubscription(),
+			"leanix_fact_sheet_tag_assignment":               resourceLeanixFactSheetTagAssignment(),
+			"leanix_technical_user":                          resourceLeanixTechnicalUser(),
+			"leanix_workspace_user":                          resourceLeanixWorkspaceUser(),
+			"leanix_data_model_field":                        resourceLeanixDataModelField(),
+			"leanix_data_model":                              resourceLeanixDataModel(),
+			"leanix_integration_api_processor_configuration": resourceLeanixIntegrationApiProcessorConfiguration(),
+			"leanix_integration_api_run":                     resourceLeanixIntegrationApiRun(),
+			"leanix_metrics_schema":                          resourceLeanixMetricsSchema(),
+			"leanix_metrics_point":                           resourceLeanixMetricsPoint(),
+			"leanix_fact_sheet_subscription":                 resourceLeanixFactSheetSubscription(),
+			"leanix_fact_sheet_document":                     resourceLeanixFactSheetDocument(),
+			"leanix_custom_report":                           resourceLeanixCustomReport(),
+			"leanix_bookmark":                                resourceLeanixBookmark(),
+			"leanix_survey":                                  resourceLeanixSurvey(),
+			"leanix_survey_run":                              resourceLeanixSurveyRun(),
+			"leanix_automation":                              resourceLeanixAutomation(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"leanix_workspace_users":   dataSourceLeanixWorkspaceUsers(),
+			"leanix_ldif":              dataSourceLeanixLdif(),
+			"leanix_metrics_point":     dataSourceLeanixMetricsPoint(),
+			"leanix_current_workspace": dataSourceLeanixCurrentWorkspace(),
 		},
 		ConfigureFunc: configureProvider,
 	}
+
+	for name, resource := range provider.ResourcesMap {
+		withTracing(name, withWorkspace(resource, false), false)
+	}
+	for name, dataSource := range provider.DataSourcesMap {
+		if !offlineDataSources[name] {
+			withWorkspace(dataSource, true)
+		}
+		withTracing(name, dataSource, true)
+	}
+	return provider
+}
+
+// Data sources which never call LeanIX, so they neither need a client nor
+// a workspace and also work if the provider has no auth_header.
+var offlineDataSources = map[string]bool{
+	"leanix_ldif": true,
 }
 
 func configureProvider(d *schema.ResourceData) (interface{}, error) {
-	return NewLeanixClient(
-		d.Get("url").(string),
+	configureTracing()
+
+	url, err := resolveLeanixUrl(d.Get("url").(string), d.Get("region").(string), defaultLeanixUrl)
+	if err != nil {
+		return nil, err
+	}
+	leanixClient := NewLeanixClient(
+		url,
 		d.Get("auth_header").(string),
-	), nil
+	)
+	// LeanIX throttles per token, so each workspace gets a limiter of its own
+	requestsPerSecond := d.Get("requests_per_second").(float64)
+	maxConcurrentRequests := d.Get("max_concurrent_requests").(int)
+	leanixClient.limiter = newRateLimiter(requestsPerSecond, maxConcurrentRequests)
+
+	// all clients share the workspaces, so any of them can look up the others
+	workspaces := map[string]*LeanixClient{}
+	for _, workspace := range d.Get("workspace").([]interface{}) {
+		castedWorkspace := workspace.(map[string]interface{})
+		name := castedWorkspace["name"].(string)
+		if _, ok := workspaces[name]; ok {
+			return nil, errors.New("Workspace '" + name + "' is configured more than once.")
+		}
+		workspaceUrl, err := resolveLeanixUrl(castedWorkspace["url"].(string), castedWorkspace["region"].(string), url)
+		if err != nil {
+			return nil, errors.New("Workspace '" + name + "': " + err.Error())
+		}
+		workspaces[name] = NewLeanixClient(workspaceUrl, castedWorkspace["auth_header"].(string))
+		workspaces[name].workspaces = workspaces
+		workspaces[name].limiter = newRateLimiter(requestsPerSecond, maxConcurrentRequests)
+	}
+	leanixClient.workspaces = workspaces
+
+	if !d.Get("skip_connectivity_check").(bool) {
+		if leanixClient.authHeader != "" {
+			if err := leanixClient.checkConnectivity(); err != nil {
+				return nil, err
+			}
+		}
+		for name, workspaceClient := range workspaces {
+			if err := workspaceClient.checkConnectivity(); err != nil {
+				return nil, errors.New("Workspace '" + name + "': " + err.Error())
+			}
+		}
+	}
+
+	return leanixClient, nil
 }
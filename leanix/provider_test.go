@@ -1,9 +1,14 @@
 package leanix
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/codecentric/terraform-provider-leanix/leanix/fake"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -28,11 +33,113 @@ func init() {
 	}
 }
 
+var startFakeLeanixOnce sync.Once
+
+// Without LEANIX_URL the acceptance tests run against the in-memory fake
+// LeanIX server, which lives until the test binary exits.
 func testAccPreCheck(t *testing.T) {
 	if os.Getenv("LEANIX_URL") == "" {
-		t.Fatal("LEANIX_URL must be set for acceptance tests")
+		startFakeLeanixOnce.Do(func() {
+			fakeLeanix := httptest.NewServer(fake.NewServer())
+			os.Setenv("LEANIX_URL", fakeLeanix.URL)
+			os.Setenv("LEANIX_AUTH_HEADER", fake.AuthHeader("fake-api-token"))
+		})
 	}
 	if os.Getenv("LEANIX_AUTH_HEADER") == "" {
 		t.Fatal("LEANIX_AUTH_HEADER must be set for acceptance tests")
 	}
 }
+
+func TestConfigureProviderConnectivityCheck(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, _ := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+		},
+	)
+	defer testServer.Close()
+
+	_, err := configureTestProvider(t, map[string]interface{}{
+		"url":                     testServer.URL + "/",
+		"auth_header":             leanixBasicAuthHeader,
+		"skip_connectivity_check": false,
+	})
+	if err != nil {
+		t.Fatalf("Expected the connectivity check to succeed, got: %s", err)
+	}
+
+	authRoute.ResponseStatus = func(header http.Header, body []byte) int {
+		return http.StatusUnauthorized
+	}
+	_, err = configureTestProvider(t, map[string]interface{}{
+		"url":                     testServer.URL,
+		"auth_header":             leanixBasicAuthHeader,
+		"skip_connectivity_check": false,
+	})
+	if err == nil || !strings.Contains(err.Error(), "rejected the auth_header") {
+		t.Fatalf("Expected the API token to be rejected, got: %v", err)
+	}
+
+	notLeanix := httptest.NewServer(http.NotFoundHandler())
+	defer notLeanix.Close()
+	_, err = configureTestProvider(t, map[string]interface{}{
+		"auth_header": leanixBasicAuthHeader,
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "url": notLeanix.URL, "auth_header": leanixBasicAuthHeader},
+		},
+		"url":                     testServer.URL,
+		"skip_connectivity_check": true,
+	})
+	if err != nil {
+		t.Fatalf("Expected no connectivity check, got: %s", err)
+	}
+	_, err = configureTestProvider(t, map[string]interface{}{
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "url": notLeanix.URL, "auth_header": leanixBasicAuthHeader},
+		},
+		"skip_connectivity_check": false,
+	})
+	if err == nil || !strings.Contains(err.Error(), "doesn't look like a LeanIX service URL") {
+		t.Fatalf("Expected the URL to be rejected, got: %v", err)
+	}
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	_, err = configureTestProvider(t, map[string]interface{}{
+		"url":                     unreachable.URL,
+		"auth_header":             leanixBasicAuthHeader,
+		"skip_connectivity_check": false,
+	})
+	if err == nil || !strings.Contains(err.Error(), "Cannot reach LeanIX") {
+		t.Fatalf("Expected LeanIX to be unreachable, got: %v", err)
+	}
+}
+
+func TestConfigureProviderRegion(t *testing.T) {
+	t.Setenv("LEANIX_URL", "")
+	client, err := configureTestProvider(t, map[string]interface{}{
+		"region":      "us",
+		"auth_header": "Basic cHJvZHVjdGlvbg==",
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+			map[string]interface{}{"name": "swiss", "region": "ch", "auth_header": "Basic c3dpc3M="},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, client.url, "https://us-svc.leanix.net")
+	assertEqual(t, client.workspaces["sandbox"].url, "https://us-svc.leanix.net")
+	assertEqual(t, client.workspaces["swiss"].url, "https://ch-svc.leanix.net")
+
+	_, err = configureTestProvider(t, map[string]interface{}{
+		"url":         "https://eu-svc.leanix.net",
+		"region":      "us",
+		"auth_header": "Basic cHJvZHVjdGlvbg==",
+	})
+	if err == nil {
+		t.Fatal("Expected an error if url and region are both set")
+	}
+}
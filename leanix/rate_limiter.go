@@ -0,0 +1,91 @@
+package leanix
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limits the requests of a client and all clients derived from it, as LeanIX
+// throttles per token while Terraform runs several operations in parallel.
+// The rate is enforced with a token bucket, which holds up to a second's worth
+// of requests, so short bursts aren't delayed. A nil limiter doesn't limit.
+type rateLimiter struct {
+	requestsPerSecond float64
+	burst             float64
+	tokens            float64
+	refilled          time.Time
+	// a slot is taken while a request is in flight, if the concurrency is limited
+	slots chan struct{}
+	sync.Mutex
+}
+
+// Create a limiter for the given rate and number of concurrent requests.
+// Zero doesn't limit, so without any limit no limiter is needed.
+func newRateLimiter(requestsPerSecond float64, maxConcurrentRequests int) *rateLimiter {
+	if requestsPerSecond <= 0 && maxConcurrentRequests <= 0 {
+		return nil
+	}
+
+	limiter := &rateLimiter{requestsPerSecond: requestsPerSecond}
+	if requestsPerSecond > 0 {
+		limiter.burst = math.Max(1, math.Floor(requestsPerSecond))
+		limiter.tokens = limiter.burst
+		limiter.refilled = time.Now()
+	}
+	if maxConcurrentRequests > 0 {
+		limiter.slots = make(chan struct{}, maxConcurrentRequests)
+	}
+	return limiter
+}
+
+// Wait until a request may be sent. The returned function must be called once
+// the response has been read, to let the next request in.
+func (limiter *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if limiter.slots != nil {
+		select {
+		case limiter.slots <- struct{}{}:
+			release = func() { <-limiter.slots }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if wait := limiter.reserve(); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+	return release, nil
+}
+
+// Take a token from the bucket and return how long to wait until it is
+// available. The bucket goes into debt for waiting requests, so they are let
+// in one after the other at the configured rate.
+func (limiter *rateLimiter) reserve() time.Duration {
+	if limiter.requestsPerSecond <= 0 {
+		return 0
+	}
+
+	limiter.Lock()
+	defer limiter.Unlock()
+	now := time.Now()
+	limiter.tokens = math.Min(limiter.burst, limiter.tokens+now.Sub(limiter.refilled).Seconds()*limiter.requestsPerSecond)
+	limiter.refilled = now
+	limiter.tokens--
+	if limiter.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-limiter.tokens / limiter.requestsPerSecond * float64(time.Second))
+}
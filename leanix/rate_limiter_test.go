@@ -0,0 +1,151 @@
+package leanix
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterWithoutLimits(t *testing.T) {
+	limiter := newRateLimiter(0, 0)
+	if limiter != nil {
+		t.Fatal("Expected no limiter without limits")
+	}
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire() returned an error: %s", err)
+	}
+	release()
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	limiter := newRateLimiter(10, 0)
+	for i := 0; i < 10; i++ {
+		if wait := limiter.reserve(); wait != 0 {
+			t.Fatalf("Expected a burst of 10 requests not to wait, but request %d waits %s", i+1, wait)
+		}
+	}
+	for i, expected := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond} {
+		wait := limiter.reserve()
+		if wait <= expected-20*time.Millisecond || wait > expected {
+			t.Errorf("Expected request %d to wait about %s, but it waits %s", 11+i, expected, wait)
+		}
+	}
+}
+
+func TestRateLimiterConcurrency(t *testing.T) {
+	limiter := newRateLimiter(0, 2)
+	releaseFirst, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire() returned an error: %s", err)
+	}
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() returned an error: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Fatal("Expected a third request to wait until its context is done")
+	}
+
+	releaseFirst()
+	if _, err := limiter.acquire(context.Background()); err != nil {
+		t.Fatalf("acquire() returned an error after a request finished: %s", err)
+	}
+}
+
+func TestSendSharesRateLimiter(t *testing.T) {
+	var lock sync.Mutex
+	inFlight, maxInFlight, requests := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		inFlight++
+		requests++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		lock.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		lock.Lock()
+		inFlight--
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	client := NewLeanixClient(server.URL, "")
+	client.limiter = newRateLimiter(50, 2)
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 60; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// derived clients, e.g. of parallel resource operations, share the limiter
+			_, _, err := client.withContext(context.Background()).send("GET", server.URL, http.Header{}, nil)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assertEqual(t, requests, 60)
+	if maxInFlight > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, but got %d", maxInFlight)
+	}
+	// a burst of 50 requests, the remaining 10 at 50 per second
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("Expected 60 requests at 50 per second to take at least 200ms, but they took %s", elapsed)
+	}
+}
+
+func TestConfigureProviderRateLimiter(t *testing.T) {
+	client, err := configureTestProvider(t, map[string]interface{}{
+		"url":                     "https://eu-svc.leanix.net",
+		"auth_header":             "Basic cHJvZHVjdGlvbg==",
+		"requests_per_second":     2.5,
+		"max_concurrent_requests": 4,
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, client.limiter.requestsPerSecond, 2.5)
+	assertEqual(t, cap(client.limiter.slots), 4)
+	sandbox := client.workspaces["sandbox"]
+	assertEqual(t, sandbox.limiter.requestsPerSecond, 2.5)
+	if sandbox.limiter == client.limiter {
+		t.Error("Expected each workspace to have a limiter of its own, as LeanIX throttles per token")
+	}
+
+	t.Setenv("LEANIX_REQUESTS_PER_SECOND", "0.5")
+	t.Setenv("LEANIX_MAX_CONCURRENT_REQUESTS", "1")
+	client, err = configureTestProvider(t, map[string]interface{}{
+		"url":         "https://eu-svc.leanix.net",
+		"auth_header": "Basic cHJvZHVjdGlvbg==",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, client.limiter.requestsPerSecond, 0.5)
+	assertEqual(t, cap(client.limiter.slots), 1)
+
+	t.Setenv("LEANIX_REQUESTS_PER_SECOND", "")
+	t.Setenv("LEANIX_MAX_CONCURRENT_REQUESTS", "")
+	client, err = configureTestProvider(t, map[string]interface{}{
+		"url":         "https://eu-svc.leanix.net",
+		"auth_header": "Basic cHJvZHVjdGlvbg==",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.limiter != nil {
+		t.Error("Expected no limiter by default")
+	}
+}
@@ -0,0 +1,83 @@
+// Package redact removes secrets like tokens and credentials from HTTP headers
+// and bodies before they are logged or stored.
+package redact
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// Placeholder replacing secret values.
+const Placeholder = "REDACTED"
+
+// JSON and form fields whose values are secret, e.g. the access token issued
+// by MTM, the API token of a technical user or the authorization header a
+// webhook subscription sends to its target.
+var secretFields = map[string]bool{
+	"access_token":        true,
+	"refresh_token":       true,
+	"id_token":            true,
+	"client_secret":       true,
+	"password":            true,
+	"apiToken":            true,
+	"authorizationHeader": true,
+}
+
+var secretHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// Copy the header with the values of secret headers replaced.
+func Header(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range secretHeaders {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, Placeholder)
+		}
+	}
+	return redacted
+}
+
+// Replace the values of secret fields in a JSON or form body. Other bodies are
+// returned as they are. JSON is detected by parsing, so the content type only
+// matters for forms.
+func Body(contentType string, body []byte) string {
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		redacted, err := json.Marshal(jsonValue(parsed))
+		if err == nil {
+			return string(redacted)
+		}
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			for key := range values {
+				if secretFields[key] {
+					values.Set(key, Placeholder)
+				}
+			}
+			return values.Encode()
+		}
+	}
+	return string(body)
+}
+
+func jsonValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, nested := range typed {
+			if secretFields[key] && nested != nil {
+				typed[key] = Placeholder
+			} else {
+				typed[key] = jsonValue(nested)
+			}
+		}
+	case []interface{}:
+		for i, nested := range typed {
+			typed[i] = jsonValue(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,40 @@
+package redact
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer secret")
+	header.Set("Content-Type", "application/json")
+
+	redacted := Header(header)
+	if redacted.Get("Authorization") != Placeholder || redacted.Get("Content-Type") != "application/json" {
+		t.Fatalf("Expected only the authorization to be redacted, got %v", redacted)
+	}
+	if header.Get("Authorization") != "Bearer secret" {
+		t.Fatal("Expected the original header to be unchanged")
+	}
+}
+
+func TestBody(t *testing.T) {
+	for _, testCase := range []struct {
+		contentType string
+		body        string
+		expected    string
+	}{
+		{"application/json", `{"access_token": "secret", "token_type": "Bearer"}`, `{"access_token":"REDACTED","token_type":"Bearer"}`},
+		{"application/json", `{"data": [{"authorizationHeader": "Basic secret", "apiToken": null}]}`, `{"data":[{"apiToken":null,"authorizationHeader":"REDACTED"}]}`},
+		{"application/x-www-form-urlencoded", "grant_type=client_credentials&client_secret=secret", "client_secret=REDACTED&grant_type=client_credentials"},
+		{"application/x-www-form-urlencoded; charset=utf-8", "grant_type=client_credentials", "grant_type=client_credentials"},
+		{"text/plain", "access_token=secret", "access_token=secret"},
+		{"", "", ""},
+	} {
+		redacted := Body(testCase.contentType, []byte(testCase.body))
+		if redacted != testCase.expected {
+			t.Fatalf("Expected %s to be redacted to %s, got %s", testCase.body, testCase.expected, redacted)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package leanix
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixAutomation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixAutomationCreate,
+		Read:   resourceLeanixAutomationRead,
+		Update: resourceLeanixAutomationUpdate,
+		Delete: resourceLeanixAutomationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"trigger": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The event starting the automation as JSON object.",
+				ValidateFunc: validation.ValidateJsonString,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+			"conditions": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "[]",
+				Description:  "The conditions which must all hold for the actions to run as JSON array.",
+				ValidateFunc: validateJsonArrayString,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+			"actions": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The actions to run as JSON array.",
+				ValidateFunc: validateJsonArrayString,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+		},
+	}
+}
+
+func resourceLeanixAutomationCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	created, err := leanixClient.CreateAutomation(extractAutomation(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*created.Id)
+	return resourceLeanixAutomationRead(d, meta)
+}
+
+func resourceLeanixAutomationRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	automationId := d.Id()
+	if automationId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	automation, err := leanixClient.ReadAutomation(automationId)
+	if err != nil {
+		return err
+	}
+	if automation == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", automation.Name)
+	d.Set("description", automation.Description)
+	d.Set("enabled", automation.Enabled)
+	// only the configured keys, LeanIX fills the trigger with defaults
+	d.Set("trigger", projectJsonDocument(string(automation.Trigger), d.Get("trigger").(string)))
+	d.Set("conditions", normalizeJsonArray(automation.Conditions))
+	d.Set("actions", normalizeJsonArray(automation.Actions))
+
+	return nil
+}
+
+func resourceLeanixAutomationUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	automationId := d.Id()
+	if automationId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	automation := extractAutomation(d)
+	automation.Id = &automationId
+	_, err := leanixClient.UpdateAutomation(automation)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixAutomationRead(d, meta)
+}
+
+func resourceLeanixAutomationDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	automationId := d.Id()
+	if automationId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteAutomation(automationId)
+}
+
+func extractAutomation(d *schema.ResourceData) Automation {
+	return Automation{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Enabled:     d.Get("enabled").(bool),
+		Trigger:     json.RawMessage(d.Get("trigger").(string)),
+		Conditions:  json.RawMessage(d.Get("conditions").(string)),
+		Actions:     json.RawMessage(d.Get("actions").(string)),
+	}
+}
@@ -0,0 +1,42 @@
+package leanix
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceLeanixAutomationReadConfiguredTrigger(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/automations/v1/automations/automation", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{"Authorization": authHeader},
+				ExpectedBody:   []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusOK
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte(`{"status":"OK","data":{"id":"automation","name":"Notify","enabled":true,"trigger":{"type":"FACT_SHEET_CREATED","factSheetType":"Application","id":"trigger","filters":[]},"conditions":[],"actions":[{"type":"SEND_NOTIFICATION"}]}}`)
+				},
+			},
+		},
+	)
+	defer testServer.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceLeanixAutomation().Schema, map[string]interface{}{
+		"name":    "Notify",
+		"trigger": `{"type": "FACT_SHEET_CREATED", "factSheetType": "Application"}`,
+		"actions": `[{"type": "SEND_NOTIFICATION"}]`,
+	})
+	d.SetId("automation")
+	err := resourceLeanixAutomationRead(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+	if err != nil {
+		t.Fatalf("resourceLeanixAutomationRead() returned an error: %s", err)
+	}
+	assertEqual(t, d.Get("trigger"), `{"factSheetType":"Application","type":"FACT_SHEET_CREATED"}`)
+}
@@ -0,0 +1,129 @@
+package leanix
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixBookmark() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixBookmarkCreate,
+		Read:   resourceLeanixBookmarkRead,
+		Update: resourceLeanixBookmarkUpdate,
+		Delete: resourceLeanixBookmarkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(bookmarkTypes, false),
+			},
+			"sharing": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "SHARED",
+				ValidateFunc: validation.StringInSlice(bookmarkSharings, false),
+			},
+			"state": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The state of the view, e.g. filters and columns, as JSON object.",
+				ValidateFunc: validateJsonObjectString,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+		},
+	}
+}
+
+func resourceLeanixBookmarkCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	created, err := leanixClient.CreateBookmark(extractBookmark(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*created.Id)
+	return resourceLeanixBookmarkRead(d, meta)
+}
+
+func resourceLeanixBookmarkRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	bookmarkId := d.Id()
+	if bookmarkId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	bookmark, err := leanixClient.ReadBookmark(bookmarkId)
+	if err != nil {
+		return err
+	}
+	if bookmark == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", bookmark.Name)
+	d.Set("description", bookmark.Description)
+	d.Set("type", bookmark.Type)
+	d.Set("sharing", bookmark.Sharing)
+	// only the configured keys, LeanIX adds defaults to the state of the view
+	d.Set("state", projectJsonDocument(string(bookmark.State), d.Get("state").(string)))
+
+	return nil
+}
+
+func resourceLeanixBookmarkUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	bookmarkId := d.Id()
+	if bookmarkId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	bookmark := extractBookmark(d)
+	bookmark.Id = &bookmarkId
+	_, err := leanixClient.UpdateBookmark(bookmark)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixBookmarkRead(d, meta)
+}
+
+func resourceLeanixBookmarkDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	bookmarkId := d.Id()
+	if bookmarkId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteBookmark(bookmarkId)
+}
+
+func extractBookmark(d *schema.ResourceData) Bookmark {
+	return Bookmark{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Type:        d.Get("type").(string),
+		Sharing:     d.Get("sharing").(string),
+		State:       json.RawMessage(d.Get("state").(string)),
+	}
+}
@@ -0,0 +1,42 @@
+package leanix
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceLeanixBookmarkReadConfiguredState(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/bookmarks/bookmark", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{"Authorization": authHeader},
+				ExpectedBody:   []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusOK
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte(`{"status":"OK","data":{"id":"bookmark","name":"Applications","type":"INVENTORY","sharing":"SHARED","state":{"filters":{"facetFilter":[],"fullTextSearch":""},"columns":["name"],"sorting":[]}}}`)
+				},
+			},
+		},
+	)
+	defer testServer.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceLeanixBookmark().Schema, map[string]interface{}{
+		"name":  "Applications",
+		"type":  "INVENTORY",
+		"state": `{"filters": {"facetFilter": []}, "columns": ["name"]}`,
+	})
+	d.SetId("bookmark")
+	err := resourceLeanixBookmarkRead(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+	if err != nil {
+		t.Fatalf("resourceLeanixBookmarkRead() returned an error: %s", err)
+	}
+	assertEqual(t, d.Get("state"), `{"columns":["name"],"filters":{"facetFilter":[]}}`)
+}
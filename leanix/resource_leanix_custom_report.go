@@ -0,0 +1,185 @@
+package leanix
+
+import (
+	"errors"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLeanixCustomReport() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLeanixCustomReportCreate,
+		Read:          resourceLeanixCustomReportRead,
+		Update:        resourceLeanixCustomReportUpdate,
+		Delete:        resourceLeanixCustomReportDelete,
+		CustomizeDiff: resourceLeanixCustomReportCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"source": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the built report, either a directory or a zip file with lxreport.json in its root.",
+			},
+			"content_hash": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 hash of the uploaded bundle. A new version is uploaded whenever it changes.",
+			},
+			"report_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixCustomReportCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	bundle, err := loadCustomReportBundle(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+	uploaded, err := leanixClient.UploadCustomReport(bundle.Zip)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(uploaded.Id)
+	d.Set("content_hash", bundle.Hash)
+	return resourceLeanixCustomReportRead(d, meta)
+}
+
+func resourceLeanixCustomReportRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	id := d.Id()
+	if id == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	report, err := leanixClient.ReadCustomReport(id)
+	if err != nil {
+		return err
+	}
+	if report == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("report_id", report.ReportId)
+	d.Set("version", report.Version)
+	d.Set("title", report.Title)
+
+	return nil
+}
+
+// Every upload creates a new entry at LeanIX, so the previous one is deleted
+// once the new version was uploaded successfully.
+func resourceLeanixCustomReportUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	previousId := d.Id()
+	if previousId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	bundle, err := loadCustomReportBundle(d.Get("source").(string))
+	if err != nil {
+		return err
+	}
+	// a moved bundle with the same content isn't uploaded again
+	previousHash, _ := d.GetChange("content_hash")
+	if bundle.Hash == previousHash.(string) {
+		d.Set("content_hash", bundle.Hash)
+		return resourceLeanixCustomReportRead(d, meta)
+	}
+	uploaded, err := leanixClient.UploadCustomReport(bundle.Zip)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(uploaded.Id)
+	d.Set("content_hash", bundle.Hash)
+	if uploaded.Id != previousId {
+		err = leanixClient.DeleteCustomReport(previousId)
+		if err != nil {
+			return err
+		}
+	}
+	return resourceLeanixCustomReportRead(d, meta)
+}
+
+func resourceLeanixCustomReportDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	id := d.Id()
+	if id == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteCustomReport(id)
+}
+
+// The bundle is hashed at plan time if it is built already, so changes of the
+// built report show up in the plan even if the source path stays the same.
+// Plans without the bundle, e.g. in CI jobs which don't build it, don't fail.
+// They plan no change for an unchanged source, so a changed bundle is only
+// uploaded by an apply of a plan made while it was built. A changed source is
+// planned as update, which reads the bundle when applying.
+func resourceLeanixCustomReportCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("source") {
+		return nil
+	}
+
+	source := d.Get("source").(string)
+	if _, err := os.Stat(source); os.IsNotExist(err) {
+		log.Printf("[WARN] Custom report bundle '%s' doesn't exist yet, changes of its content are planned once it is built.", source)
+		if d.Id() == "" || !d.HasChange("source") {
+			return nil
+		}
+		return setCustomReportBundleComputed(d)
+	}
+
+	bundle, err := loadCustomReportBundle(source)
+	if err != nil {
+		return err
+	}
+	if bundle.Hash == d.Get("content_hash").(string) {
+		return nil
+	}
+
+	err = d.SetNew("content_hash", bundle.Hash)
+	if err != nil {
+		return err
+	}
+	err = d.SetNew("report_id", bundle.Manifest.Id)
+	if err != nil {
+		return err
+	}
+	err = d.SetNew("version", bundle.Manifest.Version)
+	if err != nil {
+		return err
+	}
+	return d.SetNew("title", bundle.Manifest.Title)
+}
+
+func setCustomReportBundleComputed(d *schema.ResourceDiff) error {
+	for _, key := range []string{"content_hash", "report_id", "version", "title"} {
+		err := d.SetNewComputed(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
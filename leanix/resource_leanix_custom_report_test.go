@@ -0,0 +1,115 @@
+package leanix
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceLeanixCustomReportPlanWithoutBundle(t *testing.T) {
+	resource := resourceLeanixCustomReport()
+	state := &terraform.InstanceState{
+		ID: "report",
+		Attributes: map[string]string{
+			"id":           "report",
+			"source":       "dist/report",
+			"content_hash": "hash",
+			"report_id":    "net.example.report",
+			"version":      "1.2.0",
+			"title":        "Example",
+		},
+	}
+
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source": "dist/report",
+	}), nil)
+	if err != nil {
+		t.Fatalf("Expected a plan without the bundle to succeed, but got: %s", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("Expected no changes without the bundle, but got %v", diff.Attributes)
+	}
+
+	diff, err = resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source": "dist/other-report",
+	}), nil)
+	if err != nil {
+		t.Fatalf("Expected a plan without the bundle to succeed, but got: %s", err)
+	}
+	assertEqual(t, diff.Attributes["content_hash"].NewComputed, true)
+
+	diff, err = resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source": "dist/report",
+	}), nil)
+	if err != nil {
+		t.Fatalf("Expected a plan without the bundle to succeed, but got: %s", err)
+	}
+	assertEqual(t, diff.Attributes["content_hash"].NewComputed, true)
+}
+
+func TestResourceLeanixCustomReportMoveBundle(t *testing.T) {
+	directory := writeTestCustomReport(t, map[string]string{
+		"lxreport.json": `{"id": "net.example.report", "title": "Example", "version": "1.2.0"}`,
+		"index.html":    "<html></html>",
+	})
+	defer os.RemoveAll(directory)
+	bundle, err := loadCustomReportBundle(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// no upload route, moving the bundle must not upload it again
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/reports/report", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{"Authorization": authHeader},
+				ExpectedBody:   []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusOK
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte(`{"status":"OK","data":{"id":"report","reportId":"net.example.report","version":"1.2.0","title":"Example"}}`)
+				},
+			},
+		},
+	)
+	defer testServer.Close()
+	client := NewLeanixClient(testServer.URL, leanixBasicAuthHeader)
+
+	resource := resourceLeanixCustomReport()
+	state := &terraform.InstanceState{
+		ID: "report",
+		Attributes: map[string]string{
+			"id":           "report",
+			"source":       filepath.Join(directory, "..", "moved-away"),
+			"content_hash": bundle.Hash,
+			"report_id":    "net.example.report",
+			"version":      "1.2.0",
+			"title":        "Example",
+		},
+	}
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"source": directory,
+	}), client)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %s", err)
+	}
+	if _, ok := diff.Attributes["content_hash"]; ok {
+		t.Fatalf("Expected only the source to change, but got %v", diff.Attributes)
+	}
+
+	newState, err := resource.Apply(state, diff, client)
+	if err != nil {
+		t.Fatalf("Apply returned an error: %s", err)
+	}
+	assertEqual(t, newState.ID, "report")
+	assertEqual(t, newState.Attributes["source"], directory)
+	assertEqual(t, newState.Attributes["content_hash"], bundle.Hash)
+}
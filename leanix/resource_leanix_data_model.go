@@ -0,0 +1,172 @@
+package leanix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// There is exactly one data model per workspace.
+const dataModelId = "dataModel"
+
+func resourceLeanixDataModel() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLeanixDataModelCreate,
+		Read:          resourceLeanixDataModelRead,
+		Update:        resourceLeanixDataModelUpdate,
+		Delete:        resourceLeanixDataModelDelete,
+		CustomizeDiff: resourceLeanixDataModelCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"data_model": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The complete data model as JSON document.",
+				ValidateFunc: validateDataModelJson,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+			"allow_data_loss": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow changes deleting data from fact sheets, i.e. removing fact sheet types, fields, values or relations or changing the type of fields.",
+			},
+			"changes": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Fact sheet types, fields and relations added (+), removed (-) or changed (~) by the last plan.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceLeanixDataModelCreate(d *schema.ResourceData, meta interface{}) error {
+	err := updateDataModel(meta.(*LeanixClient), d)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(dataModelId)
+	return resourceLeanixDataModelRead(d, meta)
+}
+
+// Only the parts of the data model which are configured are stored, so parts
+// added or defaulted by LeanIX don't cause a diff. Without a configuration, e.g.
+// on import, the complete data model is stored.
+func resourceLeanixDataModelRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	dataModel, err := leanixClient.ReadDataModel()
+	if err != nil {
+		return err
+	}
+	var stored interface{} = map[string]interface{}(dataModel)
+	if configuredDocument := d.Get("data_model").(string); configuredDocument != "" {
+		var configured interface{}
+		err = json.Unmarshal([]byte(configuredDocument), &configured)
+		if err != nil {
+			return err
+		}
+		stored = projectJson(stored, configured)
+	}
+	document, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	d.Set("data_model", string(document))
+	return nil
+}
+
+func resourceLeanixDataModelUpdate(d *schema.ResourceData, meta interface{}) error {
+	err := updateDataModel(meta.(*LeanixClient), d)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixDataModelRead(d, meta)
+}
+
+// The data model of a workspace cannot be deleted, so it is only removed from the state.
+func resourceLeanixDataModelDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// Show the semantic changes of the data model in the plan and refuse changes
+// deleting data from fact sheets unless they are explicitly allowed.
+// On create the configured data model replaces the current one of the
+// workspace, so the changes are determined against it.
+func resourceLeanixDataModelCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("data_model") || !d.NewValueKnown("data_model") {
+		return nil
+	}
+
+	oldDocument, newDocument := d.GetChange("data_model")
+	oldDataModel := DataModel{}
+	if oldDocument.(string) != "" {
+		err := json.Unmarshal([]byte(oldDocument.(string)), &oldDataModel)
+		if err != nil {
+			return err
+		}
+	} else if leanixClient, ok := meta.(*LeanixClient); ok {
+		currentDataModel, err := leanixClient.ReadDataModel()
+		if err != nil {
+			return err
+		}
+		oldDataModel = currentDataModel
+	}
+	newDataModel := DataModel{}
+	err := json.Unmarshal([]byte(newDocument.(string)), &newDataModel)
+	if err != nil {
+		return err
+	}
+
+	var descriptions []string
+	var destructive []string
+	for _, change := range diffDataModels(oldDataModel, newDataModel) {
+		descriptions = append(descriptions, change.Description)
+		if change.Destructive {
+			destructive = append(destructive, change.Description)
+		}
+	}
+	if len(destructive) > 0 && !d.Get("allow_data_loss").(bool) {
+		return fmt.Errorf("The following changes delete data from fact sheets. Set allow_data_loss = true to apply them:\n%s", strings.Join(destructive, "\n"))
+	}
+	return d.SetNew("changes", descriptions)
+}
+
+func updateDataModel(leanixClient *LeanixClient, d *schema.ResourceData) error {
+	dataModel := DataModel{}
+	err := json.Unmarshal([]byte(d.Get("data_model").(string)), &dataModel)
+	if err != nil {
+		return err
+	}
+
+	unlock := leanixClient.lockDataModel()
+	defer unlock()
+	_, err = leanixClient.UpdateDataModel(dataModel, d.Get("allow_data_loss").(bool))
+	return err
+}
+
+// Validate the data model locally, so broken data models never reach LeanIX.
+func validateDataModelJson(value interface{}, key string) ([]string, []error) {
+	dataModel := DataModel{}
+	err := json.Unmarshal([]byte(value.(string)), &dataModel)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON object: %s", key, err)}
+	}
+
+	var errs []error
+	for _, validationError := range validateDataModel(dataModel) {
+		errs = append(errs, fmt.Errorf("%s is invalid: %s", key, validationError))
+	}
+	return nil, errs
+}
@@ -0,0 +1,322 @@
+package leanix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixDataModelField() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLeanixDataModelFieldCreate,
+		Read:          resourceLeanixDataModelFieldRead,
+		Update:        resourceLeanixDataModelFieldUpdate,
+		Delete:        resourceLeanixDataModelFieldDelete,
+		CustomizeDiff: resourceLeanixDataModelFieldCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fact_sheet_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(dataModelFieldTypes, false),
+			},
+			"values": &schema.Schema{
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Allowed values of SINGLE_SELECT and MULTIPLE_SELECT fields.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"translation": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"language": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value_labels": &schema.Schema{
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"allow_data_loss": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow changes deleting data from fact sheets, i.e. changing the type, removing values or deleting the field.",
+			},
+		},
+	}
+}
+
+func resourceLeanixDataModelFieldCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	factSheetType := d.Get("fact_sheet_type").(string)
+	fieldName := d.Get("name").(string)
+
+	err := updateDataModelField(leanixClient, d, true)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(factSheetType + "/" + fieldName)
+	return resourceLeanixDataModelFieldRead(d, meta)
+}
+
+func resourceLeanixDataModelFieldRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	factSheetType, fieldName, err := parseDataModelFieldId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	dataModel, err := leanixClient.ReadDataModel()
+	if err != nil {
+		return err
+	}
+	field := dataModel.Field(factSheetType, fieldName)
+	if field == nil {
+		d.SetId("")
+		return nil
+	}
+
+	var translations []interface{}
+	for _, rawTranslation := range d.Get("translation").(*schema.Set).List() {
+		language := rawTranslation.(map[string]interface{})["language"].(string)
+		translationsDocument, err := leanixClient.ReadDataModelTranslations(language)
+		if err != nil {
+			return err
+		}
+		if translation := translationsDocument.Field(factSheetType, fieldName); translation != nil {
+			translations = append(translations, packageDataModelFieldTranslation(language, translation))
+		}
+	}
+
+	fieldType, _ := field["type"].(string)
+	d.Set("fact_sheet_type", factSheetType)
+	d.Set("name", fieldName)
+	d.Set("type", fieldType)
+	d.Set("values", dataModelFieldValues(field))
+	d.Set("translation", translations)
+
+	return nil
+}
+
+func resourceLeanixDataModelFieldUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	err := updateDataModelField(leanixClient, d, false)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixDataModelFieldRead(d, meta)
+}
+
+func resourceLeanixDataModelFieldDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	factSheetType, fieldName, err := parseDataModelFieldId(d.Id())
+	if err != nil {
+		return err
+	}
+	if !d.Get("allow_data_loss").(bool) {
+		return errors.New("Deleting field '" + fieldName + "' of fact sheet type '" + factSheetType + "' deletes its data from all fact sheets. Set allow_data_loss = true to delete it.")
+	}
+
+	unlock := leanixClient.lockDataModel()
+	defer unlock()
+	dataModel, err := leanixClient.ReadDataModel()
+	if err != nil {
+		return err
+	}
+	if dataModel.Field(factSheetType, fieldName) == nil {
+		return nil
+	}
+	err = dataModel.SetField(factSheetType, fieldName, nil)
+	if err != nil {
+		return err
+	}
+	_, err = leanixClient.UpdateDataModel(dataModel, true)
+	return err
+}
+
+// Refuse changes deleting data from fact sheets already at plan time unless they are explicitly allowed.
+func resourceLeanixDataModelFieldCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	// a replacement deletes the old field with the allow_data_loss of the state
+	if d.HasChange("name") || d.HasChange("fact_sheet_type") {
+		oldAllowDataLoss, _ := d.GetChange("allow_data_loss")
+		if !oldAllowDataLoss.(bool) {
+			oldFactSheetType, _ := d.GetChange("fact_sheet_type")
+			oldName, _ := d.GetChange("name")
+			return fmt.Errorf("Renaming field '%s' of fact sheet type '%s' replaces it and deletes its data from all fact sheets. Apply allow_data_loss = true before renaming it.", oldName, oldFactSheetType)
+		}
+	}
+	if d.Get("allow_data_loss").(bool) {
+		return nil
+	}
+
+	fieldName := d.Get("name").(string)
+	if d.HasChange("type") {
+		oldType, newType := d.GetChange("type")
+		return fmt.Errorf("Changing the type of field '%s' from %s to %s deletes its data from all fact sheets. Set allow_data_loss = true to change it.", fieldName, oldType, newType)
+	}
+	if d.HasChange("values") {
+		oldValues, newValues := d.GetChange("values")
+		removed := removedDataModelFieldValues(extractStrings(oldValues), extractStrings(newValues))
+		if len(removed) > 0 {
+			return fmt.Errorf("Removing the values %s of field '%s' deletes them from all fact sheets. Set allow_data_loss = true to remove them.", strings.Join(removed, ", "), fieldName)
+		}
+	}
+	return nil
+}
+
+// Only the field managed by this resource is changed, the rest of the data model
+// and unknown attributes of the field are sent back as they are.
+// The data model is locked from reading it until the last document is written,
+// so fields changed in parallel don't overwrite each other.
+func updateDataModelField(leanixClient *LeanixClient, d *schema.ResourceData, create bool) error {
+	factSheetType := d.Get("fact_sheet_type").(string)
+	fieldName := d.Get("name").(string)
+
+	unlock := leanixClient.lockDataModel()
+	defer unlock()
+	dataModel, err := leanixClient.ReadDataModel()
+	if err != nil {
+		return err
+	}
+
+	field := dataModel.Field(factSheetType, fieldName)
+	if field != nil && create {
+		return errors.New("Field '" + fieldName + "' of fact sheet type '" + factSheetType + "' already exists. Import it to manage it with Terraform.")
+	}
+	if field == nil {
+		field = map[string]interface{}{}
+	}
+	field["type"] = d.Get("type").(string)
+	values := extractStrings(d.Get("values"))
+	if len(values) > 0 {
+		field["values"] = values
+	} else {
+		delete(field, "values")
+	}
+
+	err = dataModel.SetField(factSheetType, fieldName, field)
+	if err != nil {
+		return err
+	}
+	_, err = leanixClient.UpdateDataModel(dataModel, d.Get("allow_data_loss").(bool))
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("translation") {
+		oldTranslations, newTranslations := d.GetChange("translation")
+		languages := map[string]map[string]interface{}{}
+		for _, rawTranslation := range oldTranslations.(*schema.Set).List() {
+			languages[rawTranslation.(map[string]interface{})["language"].(string)] = nil
+		}
+		for _, rawTranslation := range newTranslations.(*schema.Set).List() {
+			translation := rawTranslation.(map[string]interface{})
+			languages[translation["language"].(string)] = extractDataModelFieldTranslation(translation)
+		}
+
+		for language, translation := range languages {
+			translationsDocument, err := leanixClient.ReadDataModelTranslations(language)
+			if err != nil {
+				return err
+			}
+			translationsDocument.ensureFactSheet(factSheetType)
+			err = translationsDocument.SetField(factSheetType, fieldName, translation)
+			if err != nil {
+				return err
+			}
+			err = leanixClient.UpdateDataModelTranslations(language, translationsDocument)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func extractDataModelFieldTranslation(translation map[string]interface{}) map[string]interface{} {
+	extracted := map[string]interface{}{
+		"label": translation["label"].(string),
+	}
+	if valueLabels := translation["value_labels"].(map[string]interface{}); len(valueLabels) > 0 {
+		extracted["values"] = valueLabels
+	}
+	return extracted
+}
+
+func packageDataModelFieldTranslation(language string, translation map[string]interface{}) map[string]interface{} {
+	label, _ := translation["label"].(string)
+	valueLabels := map[string]interface{}{}
+	if rawValueLabels, ok := translation["values"].(map[string]interface{}); ok {
+		for value, rawLabel := range rawValueLabels {
+			if valueLabel, ok := rawLabel.(string); ok {
+				valueLabels[value] = valueLabel
+			}
+		}
+	}
+	return map[string]interface{}{
+		"language":     language,
+		"label":        label,
+		"value_labels": valueLabels,
+	}
+}
+
+func extractStrings(value interface{}) []string {
+	var extracted []string
+	for _, rawValue := range value.([]interface{}) {
+		extracted = append(extracted, rawValue.(string))
+	}
+	return extracted
+}
+
+// The ID of a data model field has the form '<fact sheet type>/<field name>'.
+func parseDataModelFieldId(id string) (string, string, error) {
+	if id == "" {
+		return "", "", errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("Invalid data model field ID '" + id + "'. Expected '<fact sheet type>/<field name>'.")
+	}
+	return parts[0], parts[1], nil
+}
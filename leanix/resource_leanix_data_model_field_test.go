@@ -0,0 +1,133 @@
+package leanix
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestParseDataModelFieldId(t *testing.T) {
+	factSheetType, fieldName, err := parseDataModelFieldId("Application/costCenter")
+	if err != nil {
+		t.Fatalf("parseDataModelFieldId() returned an error: %s", err)
+	}
+	assertEqual(t, factSheetType, "Application")
+	assertEqual(t, fieldName, "costCenter")
+
+	for _, invalidId := range []string{"", "Application", "Application/", "/costCenter"} {
+		if _, _, err := parseDataModelFieldId(invalidId); err == nil {
+			t.Errorf("parseDataModelFieldId(%q) should return an error", invalidId)
+		}
+	}
+}
+
+func TestPackageDataModelFieldTranslation(t *testing.T) {
+	translation := extractDataModelFieldTranslation(map[string]interface{}{
+		"language":     "de",
+		"label":        "Kostenstelle",
+		"value_labels": map[string]interface{}{"internal": "Intern"},
+	})
+	assertEqual(t, translation, map[string]interface{}{
+		"label":  "Kostenstelle",
+		"values": map[string]interface{}{"internal": "Intern"},
+	})
+
+	packaged := packageDataModelFieldTranslation("de", translation)
+	assertEqual(t, packaged, map[string]interface{}{
+		"language":     "de",
+		"label":        "Kostenstelle",
+		"value_labels": map[string]interface{}{"internal": "Intern"},
+	})
+}
+
+func TestUpdateDataModelFieldsInParallel(t *testing.T) {
+	var lock sync.Mutex
+	dataModel := DataModel{"factSheets": map[string]interface{}{"Application": map[string]interface{}{}}}
+	// a slow read makes parallel read-modify-write cycles overlap without locking
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/services/mtm/v1/oauth2/token":
+			w.Write([]byte(`{"access_token":"token","token_type":"Bearer"}`))
+		case r.URL.Path == "/services/pathfinder/v1/models/dataModel" && r.Method == "GET":
+			lock.Lock()
+			response, _ := json.Marshal(&DataModelResponse{Status: "OK", DataModel: dataModel})
+			lock.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			w.Write(response)
+		case r.URL.Path == "/services/pathfinder/v1/models/dataModel" && r.Method == "PUT":
+			updated := DataModel{}
+			json.NewDecoder(r.Body).Decode(&updated)
+			lock.Lock()
+			dataModel = updated
+			lock.Unlock()
+			json.NewEncoder(w).Encode(&DataModelResponse{Status: "OK", DataModel: updated})
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := NewLeanixClient(server.URL, "Basic YXBpdG9rZW46dG9rZW4=")
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		d := schema.TestResourceDataRaw(t, resourceLeanixDataModelField().Schema, map[string]interface{}{
+			"fact_sheet_type": "Application",
+			"name":            fmt.Sprintf("field%d", i),
+			"type":            "STRING",
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// parallel operations use clients derived from the provider's client
+			if err := updateDataModelField(client.withContext(context.Background()), d, true); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < 5; i++ {
+		if dataModel.Field("Application", fmt.Sprintf("field%d", i)) == nil {
+			t.Errorf("Expected field%d to be in the data model, but it was overwritten: %v", i, dataModel)
+		}
+	}
+}
+
+func TestDataModelFieldReplacementNeedsAllowDataLoss(t *testing.T) {
+	resource := resourceLeanixDataModelField()
+	for _, allowDataLoss := range []string{"false", "true"} {
+		state := &terraform.InstanceState{
+			ID: "Application/costCenter",
+			Attributes: map[string]string{
+				"id":              "Application/costCenter",
+				"fact_sheet_type": "Application",
+				"name":            "costCenter",
+				"type":            "STRING",
+				"allow_data_loss": allowDataLoss,
+			},
+		}
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"fact_sheet_type": "Application",
+			"name":            "costCentre",
+			"type":            "STRING",
+			"allow_data_loss": true,
+		})
+
+		_, err := resource.Diff(state, config, nil)
+		if allowDataLoss == "false" && err == nil {
+			t.Error("Expected renaming a field to fail at plan time unless allow_data_loss was applied before")
+		}
+		if allowDataLoss == "true" && err != nil {
+			t.Errorf("Expected renaming a field to be planned with allow_data_loss, but got: %s", err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package leanix
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+const testDataModelResponse = `{"status": "OK", "data": {
+  "factSheets": {
+    "Application": {"fields": {
+      "alias": {"type": "STRING", "mandatory": false},
+      "costCenter": {"type": "STRING"}
+    }}
+  },
+  "viewModel": {"colors": {}}
+}}`
+
+func newDataModelTestClient(t *testing.T) *LeanixClient {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/pathfinder/v1/models/dataModel", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{
+					"Authorization": authHeader,
+				},
+				ExpectedBody: []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusOK
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte(testDataModelResponse)
+				},
+			},
+		},
+	)
+	t.Cleanup(testServer.Close)
+	return NewLeanixClient(testServer.URL, leanixBasicAuthHeader)
+}
+
+func TestResourceLeanixDataModelReadConfiguredParts(t *testing.T) {
+	client := newDataModelTestClient(t)
+	configured := `{"factSheets":{"Application":{"fields":{"alias":{"type":"STRING"},"costCenter":{"type":"STRING"}}}}}`
+	d := schema.TestResourceDataRaw(t, resourceLeanixDataModel().Schema, map[string]interface{}{
+		"data_model": configured,
+	})
+	d.SetId(dataModelId)
+
+	err := resourceLeanixDataModelRead(d, client)
+	if err != nil {
+		t.Fatalf("resourceLeanixDataModelRead() returned an error: %s", err)
+	}
+	assertEqual(t, d.Get("data_model"), configured)
+
+	// without a configuration, e.g. on import, the complete data model is stored
+	d = schema.TestResourceDataRaw(t, resourceLeanixDataModel().Schema, map[string]interface{}{})
+	d.SetId(dataModelId)
+	err = resourceLeanixDataModelRead(d, client)
+	if err != nil {
+		t.Fatalf("resourceLeanixDataModelRead() returned an error: %s", err)
+	}
+	response := DataModelResponse{}
+	if err := json.Unmarshal([]byte(testDataModelResponse), &response); err != nil {
+		t.Fatal(err)
+	}
+	complete, err := json.Marshal(response.DataModel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, d.Get("data_model"), string(complete))
+}
+
+func TestResourceLeanixDataModelCreateDiffsAgainstWorkspace(t *testing.T) {
+	client := newDataModelTestClient(t)
+	resource := resourceLeanixDataModel()
+	configured := `{"factSheets":{"Application":{"fields":{"alias":{"type":"STRING"},"owner":{"type":"STRING"}}}}}`
+
+	_, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"data_model": configured,
+	}), client)
+	if err == nil || !strings.Contains(err.Error(), "- field Application.costCenter") {
+		t.Fatalf("Expected the create to be refused for deleting a field of the workspace, but got: %v", err)
+	}
+
+	diff, err := resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"data_model":      configured,
+		"allow_data_loss": true,
+	}), client)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %s", err)
+	}
+	assertEqual(t, diff.Attributes["changes.#"].New, "3")
+	assertEqual(t, diff.Attributes["changes.0"].New, "~ field Application.alias")
+	assertEqual(t, diff.Attributes["changes.1"].New, "- field Application.costCenter")
+	assertEqual(t, diff.Attributes["changes.2"].New, "+ field Application.owner")
+}
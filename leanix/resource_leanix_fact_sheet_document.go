@@ -0,0 +1,122 @@
+package leanix
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLeanixFactSheetDocument() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixFactSheetDocumentCreate,
+		Read:   resourceLeanixFactSheetDocumentRead,
+		Update: resourceLeanixFactSheetDocumentUpdate,
+		Delete: resourceLeanixFactSheetDocumentDelete,
+		Importer: &schema.ResourceImporter{
+			State: importFactSheetScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fact_sheet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"url": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"document_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixFactSheetDocumentCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	created, err := leanixClient.CreateFactSheetDocument(d.Get("fact_sheet_id").(string), extractFactSheetDocument(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+	return resourceLeanixFactSheetDocumentRead(d, meta)
+}
+
+func resourceLeanixFactSheetDocumentRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	documentId := d.Id()
+	if documentId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	documents, err := leanixClient.ReadFactSheetDocuments(d.Get("fact_sheet_id").(string))
+	if err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		if document.Id != documentId {
+			continue
+		}
+		d.Set("name", document.Name)
+		d.Set("url", document.Url)
+		d.Set("description", document.Description)
+		d.Set("document_type", document.DocumentType)
+		return nil
+	}
+
+	// either the fact sheet or the document was deleted outside of Terraform
+	d.SetId("")
+	return nil
+}
+
+func resourceLeanixFactSheetDocumentUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	documentId := d.Id()
+	if documentId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	document := extractFactSheetDocument(d)
+	document.Id = documentId
+	_, err := leanixClient.UpdateFactSheetDocument(document)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixFactSheetDocumentRead(d, meta)
+}
+
+func resourceLeanixFactSheetDocumentDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	documentId := d.Id()
+	if documentId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteFactSheetDocument(documentId)
+}
+
+func extractFactSheetDocument(d *schema.ResourceData) FactSheetDocument {
+	return FactSheetDocument{
+		Name:         d.Get("name").(string),
+		Url:          d.Get("url").(string),
+		Description:  d.Get("description").(string),
+		DocumentType: d.Get("document_type").(string),
+	}
+}
@@ -0,0 +1,202 @@
+package leanix
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixFactSheetSubscription() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixFactSheetSubscriptionCreate,
+		Read:   resourceLeanixFactSheetSubscriptionRead,
+		Update: resourceLeanixFactSheetSubscriptionUpdate,
+		Delete: resourceLeanixFactSheetSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: importFactSheetScopedResource,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fact_sheet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_email": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"type": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"RESPONSIBLE", "ACCOUNTABLE", "OBSERVER"}, false),
+			},
+			"roles": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Names of the subscription roles of the user.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"user_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixFactSheetSubscriptionCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	subscriptionType := d.Get("type").(string)
+	roleIds, err := resolveSubscriptionRoleIds(leanixClient, subscriptionType, d.Get("roles").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	created, err := leanixClient.CreateFactSheetSubscription(
+		d.Get("fact_sheet_id").(string),
+		d.Get("user_email").(string),
+		subscriptionType,
+		roleIds,
+	)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(created.Id)
+	return resourceLeanixFactSheetSubscriptionRead(d, meta)
+}
+
+func resourceLeanixFactSheetSubscriptionRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	subscriptionId := d.Id()
+	if subscriptionId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	subscriptions, err := leanixClient.ReadFactSheetSubscriptions(d.Get("fact_sheet_id").(string))
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.Id != subscriptionId {
+			continue
+		}
+		var roleNames []string
+		for _, role := range subscription.Roles {
+			roleNames = append(roleNames, role.Name)
+		}
+		d.Set("user_email", subscription.User.Email)
+		d.Set("user_id", subscription.User.Id)
+		d.Set("type", subscription.Type)
+		d.Set("roles", roleNames)
+		return nil
+	}
+
+	// either the fact sheet or the subscription was deleted outside of Terraform
+	d.SetId("")
+	return nil
+}
+
+func resourceLeanixFactSheetSubscriptionUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	subscriptionId := d.Id()
+	if subscriptionId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	subscriptionType := d.Get("type").(string)
+	roleIds, err := resolveSubscriptionRoleIds(leanixClient, subscriptionType, d.Get("roles").(*schema.Set))
+	if err != nil {
+		return err
+	}
+
+	_, err = leanixClient.UpdateFactSheetSubscription(subscriptionId, d.Get("user_email").(string), subscriptionType, roleIds)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixFactSheetSubscriptionRead(d, meta)
+}
+
+func resourceLeanixFactSheetSubscriptionDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	subscriptionId := d.Id()
+	if subscriptionId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteFactSheetSubscription(subscriptionId)
+}
+
+// Resources of a fact sheet, like subscriptions, can only be read through the
+// fact sheet, so the import ID has the form '<fact sheet ID>/<ID>'.
+func importFactSheetScopedResource(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, errors.New("Invalid import ID '" + d.Id() + "'. Expected '<fact sheet ID>/<ID>'.")
+	}
+	d.Set("fact_sheet_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// Resolve the names of subscription roles to their IDs. Roles are defined per
+// subscription type, so only roles of the given type are considered.
+func resolveSubscriptionRoleIds(leanixClient *LeanixClient, subscriptionType string, roleNames *schema.Set) ([]string, error) {
+	if roleNames.Len() == 0 {
+		return []string{}, nil
+	}
+
+	roles, err := leanixClient.ReadSubscriptionRoles()
+	if err != nil {
+		return nil, err
+	}
+	return matchSubscriptionRoles(roles, subscriptionType, extractSetStrings(roleNames))
+}
+
+func matchSubscriptionRoles(roles []FactSheetSubscriptionRole, subscriptionType string, roleNames []string) ([]string, error) {
+	var roleIds []string
+	var unknown []string
+	for _, roleName := range roleNames {
+		roleId := ""
+		for _, role := range roles {
+			if role.Name == roleName && (role.SubscriptionType == "" || role.SubscriptionType == subscriptionType) {
+				roleId = role.Id
+				break
+			}
+		}
+		if roleId == "" {
+			unknown = append(unknown, roleName)
+		} else {
+			roleIds = append(roleIds, roleId)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, errors.New("Unknown subscription roles for type " + subscriptionType + ": " + strings.Join(unknown, ", "))
+	}
+	return roleIds, nil
+}
+
+func extractSetStrings(set *schema.Set) []string {
+	var extracted []string
+	for _, value := range set.List() {
+		extracted = append(extracted, value.(string))
+	}
+	sort.Strings(extracted)
+	return extracted
+}
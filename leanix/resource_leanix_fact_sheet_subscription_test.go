@@ -0,0 +1,44 @@
+package leanix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestMatchSubscriptionRoles(t *testing.T) {
+	roles := []FactSheetSubscriptionRole{
+		{Id: "r1", Name: "Business Owner", SubscriptionType: "RESPONSIBLE"},
+		{Id: "r2", Name: "Business Owner", SubscriptionType: "ACCOUNTABLE"},
+		{Id: "r3", Name: "IT Owner", SubscriptionType: "RESPONSIBLE"},
+	}
+
+	roleIds, err := matchSubscriptionRoles(roles, "ACCOUNTABLE", []string{"Business Owner"})
+	if err != nil {
+		t.Fatalf("matchSubscriptionRoles() returned an error: %s", err)
+	}
+	assertEqual(t, roleIds, []string{"r2"})
+
+	_, err = matchSubscriptionRoles(roles, "ACCOUNTABLE", []string{"Business Owner", "IT Owner"})
+	if err == nil {
+		t.Fatal("matchSubscriptionRoles() should fail for roles of another subscription type")
+	}
+	assertEqual(t, err.Error(), "Unknown subscription roles for type ACCOUNTABLE: IT Owner")
+}
+
+func TestImportFactSheetScopedResource(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceLeanixFactSheetSubscription().Schema, map[string]interface{}{})
+	d.SetId("fs/subscription")
+
+	imported, err := importFactSheetScopedResource(d, nil)
+	if err != nil {
+		t.Fatalf("importFactSheetScopedResource() returned an error: %s", err)
+	}
+	assertEqual(t, imported[0].Id(), "subscription")
+	assertEqual(t, imported[0].Get("fact_sheet_id"), "fs")
+
+	d.SetId("subscription")
+	if _, err := importFactSheetScopedResource(d, nil); err == nil {
+		t.Fatal("importFactSheetScopedResource() should fail for IDs without fact sheet ID")
+	}
+}
@@ -0,0 +1,109 @@
+package leanix
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLeanixFactSheetTagAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixFactSheetTagAssignmentCreate,
+		Read:   resourceLeanixFactSheetTagAssignmentRead,
+		Delete: resourceLeanixFactSheetTagAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"fact_sheet_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tag_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"tag_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixFactSheetTagAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	factSheetId := d.Get("fact_sheet_id").(string)
+	tagId := d.Get("tag_id").(string)
+	_, err := leanixClient.AddFactSheetTag(factSheetId, tagId)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(factSheetId + "/" + tagId)
+	return resourceLeanixFactSheetTagAssignmentRead(d, meta)
+}
+
+func resourceLeanixFactSheetTagAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	factSheetId, tagId, err := parseFactSheetTagAssignmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	factSheet, err := leanixClient.ReadFactSheet(factSheetId)
+	if err != nil {
+		return err
+	}
+	if factSheet == nil {
+		d.SetId("")
+		return nil
+	}
+
+	for _, tag := range factSheet.Tags {
+		if tag.Id == tagId {
+			d.Set("fact_sheet_id", factSheetId)
+			d.Set("tag_id", tagId)
+			d.Set("tag_name", tag.Name)
+			return nil
+		}
+	}
+
+	// the tag was removed outside of Terraform
+	d.SetId("")
+	return nil
+}
+
+func resourceLeanixFactSheetTagAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	factSheetId, tagId, err := parseFactSheetTagAssignmentId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	_, err = leanixClient.RemoveFactSheetTag(factSheetId, tagId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// The ID of a tag assignment has the form '<fact sheet ID>/<tag ID>'.
+func parseFactSheetTagAssignmentId(id string) (string, string, error) {
+	if id == "" {
+		return "", "", errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("Invalid tag assignment ID '" + id + "'. Expected '<fact sheet ID>/<tag ID>'.")
+	}
+	return parts[0], parts[1], nil
+}
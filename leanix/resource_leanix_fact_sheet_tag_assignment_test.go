@@ -0,0 +1,20 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestParseFactSheetTagAssignmentId(t *testing.T) {
+	factSheetId, tagId, err := parseFactSheetTagAssignmentId("fs-1/tag-1")
+	if err != nil {
+		t.Fatalf("parseFactSheetTagAssignmentId() returned an error: %s", err)
+	}
+	assertEqual(t, factSheetId, "fs-1")
+	assertEqual(t, tagId, "tag-1")
+
+	for _, invalidId := range []string{"", "fs-1", "fs-1/", "/tag-1", "fs-1/tag-1/x"} {
+		if _, _, err := parseFactSheetTagAssignmentId(invalidId); err == nil {
+			t.Errorf("parseFactSheetTagAssignmentId(%q) should return an error", invalidId)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package leanix
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixIntegrationApiProcessorConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceLeanixIntegrationApiProcessorConfigurationCreate,
+		Read:          resourceLeanixIntegrationApiProcessorConfigurationRead,
+		Update:        resourceLeanixIntegrationApiProcessorConfigurationUpdate,
+		Delete:        resourceLeanixIntegrationApiProcessorConfigurationDelete,
+		CustomizeDiff: resourceLeanixIntegrationApiProcessorConfigurationCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"connector_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"connector_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"connector_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"processing_direction": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"inbound", "outbound"}, false),
+			},
+			"processing_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"partial", "full"}, false),
+			},
+			"processors": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The processors as JSON array.",
+				ValidateFunc: validateProcessorsJson,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+			"changes": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Processors added (+), removed (-) or changed (~) by the last plan.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceLeanixIntegrationApiProcessorConfigurationCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	// LeanIX replaces configurations with the same key, so an existing one would
+	// be overwritten silently
+	configuration := extractProcessorConfiguration(d)
+	existing, err := leanixClient.ReadProcessorConfiguration(configuration.ProcessorConfigurationKey)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return errors.New("Processor configuration '" + configuration.Id() + "' already exists. Import it to manage it with Terraform.")
+	}
+
+	_, err = leanixClient.UpsertProcessorConfiguration(configuration)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(configuration.Id())
+	return resourceLeanixIntegrationApiProcessorConfigurationRead(d, meta)
+}
+
+func resourceLeanixIntegrationApiProcessorConfigurationRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	key, err := parseProcessorConfigurationId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	configuration, err := leanixClient.ReadProcessorConfiguration(key)
+	if err != nil {
+		return err
+	}
+	if configuration == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("connector_type", key.ConnectorType)
+	d.Set("connector_id", key.ConnectorId)
+	d.Set("connector_version", key.ConnectorVersion)
+	d.Set("processing_direction", key.ProcessingDirection)
+	d.Set("processing_mode", key.ProcessingMode)
+	d.Set("processors", normalizeJsonStateFunc(string(configuration.Processors)))
+
+	return nil
+}
+
+func resourceLeanixIntegrationApiProcessorConfigurationUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	_, err := leanixClient.UpsertProcessorConfiguration(extractProcessorConfiguration(d))
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixIntegrationApiProcessorConfigurationRead(d, meta)
+}
+
+func resourceLeanixIntegrationApiProcessorConfigurationDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	key, err := parseProcessorConfigurationId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	return leanixClient.DeleteProcessorConfiguration(key)
+}
+
+// Show which processors are added, removed or changed in the plan instead of one long JSON string.
+func resourceLeanixIntegrationApiProcessorConfigurationCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("processors") || !d.NewValueKnown("processors") {
+		return nil
+	}
+
+	oldDocument, newDocument := d.GetChange("processors")
+	var oldProcessors []interface{}
+	if oldDocument.(string) != "" {
+		err := json.Unmarshal([]byte(oldDocument.(string)), &oldProcessors)
+		if err != nil {
+			return err
+		}
+	}
+	var newProcessors []interface{}
+	err := json.Unmarshal([]byte(newDocument.(string)), &newProcessors)
+	if err != nil {
+		return err
+	}
+	return d.SetNew("changes", diffProcessors(oldProcessors, newProcessors))
+}
+
+func extractProcessorConfiguration(d *schema.ResourceData) ProcessorConfiguration {
+	return ProcessorConfiguration{
+		ProcessorConfigurationKey: ProcessorConfigurationKey{
+			ConnectorType:       d.Get("connector_type").(string),
+			ConnectorId:         d.Get("connector_id").(string),
+			ConnectorVersion:    d.Get("connector_version").(string),
+			ProcessingDirection: d.Get("processing_direction").(string),
+			ProcessingMode:      d.Get("processing_mode").(string),
+		},
+		Processors: json.RawMessage(d.Get("processors").(string)),
+	}
+}
+
+func validateProcessorsJson(value interface{}, key string) ([]string, []error) {
+	var processors []map[string]interface{}
+	err := json.Unmarshal([]byte(value.(string)), &processors)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be a JSON array of processor objects: %s", key, err)}
+	}
+
+	var errs []error
+	for index, processor := range processors {
+		if _, ok := processor["processorType"].(string); !ok {
+			errs = append(errs, fmt.Errorf("%s: processor #%d must have a processorType", key, index))
+		}
+	}
+	return nil, errs
+}
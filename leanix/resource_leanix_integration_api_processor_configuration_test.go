@@ -0,0 +1,46 @@
+package leanix
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceLeanixIntegrationApiProcessorConfigurationCreateExisting(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	// no PUT route, the existing configuration must not be overwritten
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/integration-api/v1/configurations", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{"Authorization": authHeader},
+				ExpectedBody:   []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusOK
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte(`{"connectorType":"ee","connectorId":"Kub-Dev-001","connectorVersion":"1.0.0","processingDirection":"inbound","processingMode":"partial","processors":[{"processorType":"inboundFactSheet"}]}`)
+				},
+			},
+		},
+	)
+	defer testServer.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceLeanixIntegrationApiProcessorConfiguration().Schema, map[string]interface{}{
+		"connector_type":       "ee",
+		"connector_id":         "Kub-Dev-001",
+		"connector_version":    "1.0.0",
+		"processing_direction": "inbound",
+		"processing_mode":      "partial",
+		"processors":           `[{"processorType":"inboundRelation"}]`,
+	})
+	err := resourceLeanixIntegrationApiProcessorConfigurationCreate(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+	if err == nil || !strings.Contains(err.Error(), "already exists. Import it") {
+		t.Fatalf("Expected an error for an existing processor configuration, but got %v", err)
+	}
+	assertEqual(t, d.Id(), "")
+}
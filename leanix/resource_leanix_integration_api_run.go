@@ -0,0 +1,190 @@
+package leanix
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+var synchronizationRunPendingStatuses = []string{"CREATED", "PENDING", "IN_PROGRESS"}
+
+func resourceLeanixIntegrationApiRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixIntegrationApiRunCreate,
+		Read:   resourceLeanixIntegrationApiRunRead,
+		Delete: resourceLeanixIntegrationApiRunDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"ldif": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The LDIF document to synchronize. A new run is started whenever its content changes.",
+				ValidateFunc: validateLdifJson,
+				// only the hash of the content is kept in the state, documents can be large
+				StateFunc: hashLdifStateFunc,
+			},
+			"ldif_hash": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"warnings": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"stats": &schema.Schema{
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceLeanixIntegrationApiRunCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	ldif := normalizeJsonStateFunc(d.Get("ldif").(string))
+	run, err := leanixClient.StartSynchronizationRun(json.RawMessage(ldif))
+	if err != nil {
+		return err
+	}
+	// POSTs are only retried if LeanIX didn't process them, so there is exactly one
+	// run. Its ID is stored before waiting, so a run that fails or times out stays
+	// in the state (as tainted) and can be looked up in LeanIX.
+	d.SetId(run.Id)
+	d.Set("ldif_hash", hashLdif(ldif))
+
+	// StateChangeConf backs off exponentially between the polls up to 10 seconds
+	stateChangeConf := &resource.StateChangeConf{
+		Pending:    synchronizationRunPendingStatuses,
+		Target:     []string{"FINISHED"},
+		Timeout:    d.Timeout(schema.TimeoutCreate),
+		MinTimeout: 2 * time.Second,
+		Refresh: func() (interface{}, string, error) {
+			runStatus, err := leanixClient.ReadSynchronizationRunStatus(run.Id)
+			if err != nil {
+				return nil, "", err
+			}
+			if runStatus == nil {
+				return nil, "", errors.New("Synchronization run '" + run.Id + "' disappeared while waiting for it to finish.")
+			}
+			return runStatus, runStatus.Status, nil
+		},
+	}
+	_, err = stateChangeConf.WaitForState()
+	if err != nil {
+		warnings, _ := leanixClient.ReadSynchronizationRunWarnings(run.Id)
+		if len(warnings) > 0 {
+			return fmt.Errorf("Synchronization run '%s' did not finish: %s\nWarnings:\n%s", run.Id, err, strings.Join(packageSynchronizationRunWarnings(warnings), "\n"))
+		}
+		return fmt.Errorf("Synchronization run '%s' did not finish: %s", run.Id, err)
+	}
+
+	return resourceLeanixIntegrationApiRunRead(d, meta)
+}
+
+func resourceLeanixIntegrationApiRunRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	runId := d.Id()
+	if runId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	// LeanIX removes old runs after a while. The run still happened, so we keep the
+	// last known values instead of starting it again.
+	runStatus, err := leanixClient.ReadSynchronizationRunStatus(runId)
+	if err != nil {
+		return err
+	}
+	if runStatus == nil {
+		return nil
+	}
+
+	warnings, err := leanixClient.ReadSynchronizationRunWarnings(runId)
+	if err != nil {
+		return err
+	}
+	stats, err := leanixClient.ReadSynchronizationRunStats(runId)
+	if err != nil {
+		return err
+	}
+
+	d.Set("status", runStatus.Status)
+	d.Set("warnings", packageSynchronizationRunWarnings(warnings))
+	d.Set("stats", packageSynchronizationRunStats(stats))
+
+	return nil
+}
+
+// A synchronization run cannot be undone, so it is only removed from the state.
+func resourceLeanixIntegrationApiRunDelete(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func hashLdif(normalizedLdif string) string {
+	hash := sha256.Sum256([]byte(normalizedLdif))
+	return hex.EncodeToString(hash[:])
+}
+
+func hashLdifStateFunc(value interface{}) string {
+	return hashLdif(normalizeJsonStateFunc(value))
+}
+
+func packageSynchronizationRunWarnings(warnings []SynchronizationRunWarning) []string {
+	packagedWarnings := []string{}
+	for _, warning := range warnings {
+		packagedWarnings = append(packagedWarnings, warning.Message)
+	}
+	return packagedWarnings
+}
+
+// Terraform maps only hold strings, so nested statistics are kept as JSON.
+func packageSynchronizationRunStats(stats map[string]interface{}) map[string]string {
+	packagedStats := map[string]string{}
+	for key, value := range stats {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			encoded, _ := json.Marshal(value)
+			packagedStats[key] = string(encoded)
+		default:
+			packagedStats[key] = fmt.Sprint(value)
+		}
+	}
+	return packagedStats
+}
+
+func validateLdifJson(value interface{}, key string) ([]string, []error) {
+	ldif := Ldif{}
+	err := json.Unmarshal([]byte(value.(string)), &ldif)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%s must be an LDIF JSON document: %s", key, err)}
+	}
+
+	var errs []error
+	for _, validationError := range validateLdif(ldif) {
+		errs = append(errs, fmt.Errorf("%s is invalid: %s", key, validationError))
+	}
+	return nil, errs
+}
@@ -0,0 +1,79 @@
+package leanix
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestPackageSynchronizationRunStats(t *testing.T) {
+	stats := map[string]interface{}{
+		"contentItems":    float64(12),
+		"factSheets":      map[string]interface{}{"created": float64(3)},
+		"connectorFailed": false,
+	}
+	assertEqual(t, packageSynchronizationRunStats(stats), map[string]string{
+		"contentItems":    "12",
+		"factSheets":      `{"created":3}`,
+		"connectorFailed": "false",
+	})
+}
+
+func TestValidateLdifJson(t *testing.T) {
+	_, errs := validateLdifJson(`{"connectorType": "ee", "connectorId": "Kub-Dev-001", "content": []}`, "ldif")
+	if len(errs) > 0 {
+		t.Fatalf("Expected a valid LDIF but got %v", errs)
+	}
+
+	_, errs = validateLdifJson(`{"connectorType": "ee", "content": [`, "ldif")
+	if len(errs) != 1 {
+		t.Fatalf("Expected an error for invalid JSON but got %v", errs)
+	}
+}
+
+func TestHashLdifStateFunc(t *testing.T) {
+	ldif := `{"connectorId":"Kub-Dev-001","connectorType":"ee","content":[]}`
+	assertEqual(t, hashLdifStateFunc(ldif), hashLdif(ldif))
+	assertEqual(t, hashLdifStateFunc("{\n  \"content\": [],\n  \"connectorType\": \"ee\",\n  \"connectorId\": \"Kub-Dev-001\"\n}"), hashLdif(ldif))
+}
+
+func TestResourceLeanixIntegrationApiRunCreateKeepsFailedRun(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	route := func(expectedBody string, response string) *TestRouteDefinition {
+		return &TestRouteDefinition{
+			ExpectedHeader: map[string]string{"Authorization": authHeader},
+			ExpectedBody:   []byte(expectedBody),
+			ResponseStatus: func(header http.Header, body []byte) int {
+				return http.StatusOK
+			},
+			ResponseBody: func(header http.Header, body []byte) []byte {
+				return []byte(response)
+			},
+		}
+	}
+
+	ldif := `{"connectorId":"Kub-Dev-001","connectorType":"ee","content":[]}`
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}:                                authRoute,
+			TestResourceAndMethod{Resource: "/services/integration-api/v1/synchronizationRuns", Method: "POST"}:             route(ldif, `{"id":"run","status":"CREATED"}`),
+			TestResourceAndMethod{Resource: "/services/integration-api/v1/synchronizationRuns/run/status", Method: "GET"}:   route("", `{"status":"FAILED"}`),
+			TestResourceAndMethod{Resource: "/services/integration-api/v1/synchronizationRuns/run/warnings", Method: "GET"}: route("", `[{"message":"Unknown fact sheet type"}]`),
+		},
+	)
+	defer testServer.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceLeanixIntegrationApiRun().Schema, map[string]interface{}{
+		"ldif": "{\n  \"connectorType\": \"ee\",\n  \"connectorId\": \"Kub-Dev-001\",\n  \"content\": []\n}",
+	})
+	err := resourceLeanixIntegrationApiRunCreate(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+	if err == nil || !strings.Contains(err.Error(), "Unknown fact sheet type") {
+		t.Fatalf("Expected the failed run to be reported with its warnings, but got %v", err)
+	}
+	assertEqual(t, d.Id(), "run")
+	assertEqual(t, d.Get("ldif_hash"), hashLdif(ldif))
+}
@@ -0,0 +1,165 @@
+package leanix
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Metrics points cannot be changed, so every change writes a new point.
+func resourceLeanixMetricsPoint() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixMetricsPointCreate,
+		Read:   resourceLeanixMetricsPointRead,
+		Delete: resourceLeanixMetricsPointDelete,
+
+		Schema: map[string]*schema.Schema{
+			"schema_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"timestamp": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+			},
+			"dimensions": &schema.Schema{
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Values of the dimension and fact sheet ID attributes of the schema.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metrics": &schema.Schema{
+				Type:        schema.TypeMap,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Values of the metric attributes of the schema.",
+				Elem: &schema.Schema{
+					Type: schema.TypeFloat,
+				},
+			},
+		},
+	}
+}
+
+func resourceLeanixMetricsPointCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	schemaId := d.Get("schema_id").(string)
+	timestamp := d.Get("timestamp").(string)
+	point := newMetricsPoint(timestamp, d.Get("dimensions").(map[string]interface{}), d.Get("metrics").(map[string]interface{}))
+	err := leanixClient.CreateMetricsPoint(schemaId, point)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(metricsPointId(schemaId, timestamp, d.Get("dimensions").(map[string]interface{})))
+	return nil
+}
+
+func resourceLeanixMetricsPointRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	schemaId, timestamp, err := parseMetricsPointId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	metricsSchema, err := leanixClient.ReadMetricsSchema(schemaId)
+	if err != nil {
+		return err
+	}
+	if metricsSchema == nil {
+		d.SetId("")
+		return nil
+	}
+	points, err := leanixClient.QueryMetricsPoints(schemaId, timestamp, timestamp, "")
+	if err != nil {
+		return err
+	}
+
+	// the same comparison as in Delete, so a point is only adopted if Delete
+	// would delete it as well
+	for _, point := range points {
+		if !sameMetricsTimestamp(point.Timestamp(), timestamp) {
+			continue
+		}
+		dimensions, metrics := point.split(metricsSchema)
+		if !sameMetricsDimensions(dimensions, d.Get("dimensions").(map[string]interface{})) {
+			continue
+		}
+		d.Set("dimensions", dimensions)
+		d.Set("metrics", metrics)
+		return nil
+	}
+
+	// the point was deleted outside of Terraform
+	d.SetId("")
+	return nil
+}
+
+// LeanIX deletes points by time range only, which would also delete points with
+// the same timestamp but other dimensions. So the point is only deleted if it is
+// the only one at its timestamp, otherwise it is just removed from the state.
+func resourceLeanixMetricsPointDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	schemaId, timestamp, err := parseMetricsPointId(d.Id())
+	if err != nil {
+		return err
+	}
+
+	metricsSchema, err := leanixClient.ReadMetricsSchema(schemaId)
+	if err != nil {
+		return err
+	}
+	if metricsSchema == nil {
+		return nil
+	}
+	points, err := leanixClient.QueryMetricsPoints(schemaId, timestamp, timestamp, "")
+	if err != nil {
+		return err
+	}
+	for _, point := range points {
+		dimensions, _ := point.split(metricsSchema)
+		if !sameMetricsDimensions(dimensions, d.Get("dimensions").(map[string]interface{})) {
+			log.Printf("[WARN] Metrics point '%s' stays in LeanIX, as deleting it would delete other points of schema '%s' at %s as well.", d.Id(), schemaId, timestamp)
+			return nil
+		}
+	}
+
+	return leanixClient.DeleteMetricsPoints(schemaId, timestamp, timestamp)
+}
+
+// The ID of a metrics point has the form '<schema ID>/<timestamp>/<dimensions>'
+// with the dimensions URL encoded in the order of their names, so points with
+// the same timestamp but other dimensions get different IDs.
+func metricsPointId(schemaId string, timestamp string, dimensions map[string]interface{}) string {
+	values := url.Values{}
+	for name, value := range dimensions {
+		values.Set(name, fmt.Sprint(value))
+	}
+	return schemaId + "/" + timestamp + "/" + values.Encode()
+}
+
+// Parse the schema ID and timestamp of a metrics point ID.
+func parseMetricsPointId(id string) (string, string, error) {
+	if id == "" {
+		return "", "", errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+	parts := strings.SplitN(id, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.New("Invalid metrics point ID '" + id + "'. Expected '<schema ID>/<timestamp>/<dimensions>'.")
+	}
+	return parts[0], parts[1], nil
+}
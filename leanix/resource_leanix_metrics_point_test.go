@@ -0,0 +1,149 @@
+package leanix
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceLeanixMetricsPointDelete(t *testing.T) {
+	ownPoint := MetricsPoint{"timestamp": "2024-01-01T00:00:00Z", "environment": "prod", "count": 3}
+	otherPoint := MetricsPoint{"timestamp": "2024-01-01T00:00:00Z", "environment": "test", "count": 5}
+
+	for _, testCase := range []struct {
+		name           string
+		points         []MetricsPoint
+		expectedDelete bool
+	}{
+		{"only point at the timestamp", []MetricsPoint{ownPoint}, true},
+		{"other point at the timestamp", []MetricsPoint{ownPoint, otherPoint}, false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+			authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+			deleted := false
+			getRoute := func(response interface{}) *TestRouteDefinition {
+				return &TestRouteDefinition{
+					ExpectedHeader: map[string]string{"Authorization": authHeader},
+					ExpectedBody:   []byte{},
+					ResponseStatus: func(header http.Header, body []byte) int {
+						return http.StatusOK
+					},
+					ResponseBody: func(header http.Header, body []byte) []byte {
+						responseMarshal, err := json.Marshal(response)
+						if err != nil {
+							t.Fatal(err)
+						}
+						return responseMarshal
+					},
+				}
+			}
+			schemaId := "schema"
+			testServer := NewTestServer(
+				t,
+				TestRoute{
+					TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+					TestResourceAndMethod{Resource: "/services/metrics/v2/schemas/schema", Method: "GET"}: getRoute(&MetricsSchema{
+						Id:   &schemaId,
+						Name: "deployments",
+						Attributes: []MetricsSchemaAttribute{
+							{Name: "environment", Type: "dimension"},
+							{Name: "count", Type: "metric"},
+						},
+					}),
+					TestResourceAndMethod{Resource: "/services/metrics/v2/schemas/schema/points", Method: "GET"}: getRoute(testCase.points),
+					TestResourceAndMethod{Resource: "/services/metrics/v2/schemas/schema/points", Method: "DELETE"}: &TestRouteDefinition{
+						ExpectedHeader: map[string]string{"Authorization": authHeader},
+						ExpectedBody:   []byte{},
+						ResponseStatus: func(header http.Header, body []byte) int {
+							deleted = true
+							return http.StatusNoContent
+						},
+						ResponseBody: func(header http.Header, body []byte) []byte {
+							return []byte{}
+						},
+					},
+				},
+			)
+			defer testServer.Close()
+
+			d := schema.TestResourceDataRaw(t, resourceLeanixMetricsPoint().Schema, map[string]interface{}{
+				"schema_id":  "schema",
+				"timestamp":  "2024-01-01T00:00:00Z",
+				"dimensions": map[string]interface{}{"environment": "prod"},
+				"metrics":    map[string]interface{}{"count": 3},
+			})
+			d.SetId("schema/2024-01-01T00:00:00Z/environment=prod")
+			err := resourceLeanixMetricsPointDelete(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+			if err != nil {
+				t.Fatalf("resourceLeanixMetricsPointDelete() returned an error: %s", err)
+			}
+			assertEqual(t, deleted, testCase.expectedDelete)
+		})
+	}
+}
+
+func TestResourceLeanixMetricsPointRead(t *testing.T) {
+	for _, testCase := range []struct {
+		name          string
+		point         MetricsPoint
+		expectedFound bool
+	}{
+		{"same dimensions", MetricsPoint{"timestamp": "2024-01-01T00:00:00Z", "environment": "prod", "count": 3}, true},
+		{"more dimensions", MetricsPoint{"timestamp": "2024-01-01T00:00:00Z", "environment": "prod", "region": "eu", "count": 3}, false},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+			authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+			getRoute := func(response interface{}) *TestRouteDefinition {
+				return &TestRouteDefinition{
+					ExpectedHeader: map[string]string{"Authorization": authHeader},
+					ExpectedBody:   []byte{},
+					ResponseStatus: func(header http.Header, body []byte) int {
+						return http.StatusOK
+					},
+					ResponseBody: func(header http.Header, body []byte) []byte {
+						responseMarshal, err := json.Marshal(response)
+						if err != nil {
+							t.Fatal(err)
+						}
+						return responseMarshal
+					},
+				}
+			}
+			schemaId := "schema"
+			testServer := NewTestServer(
+				t,
+				TestRoute{
+					TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+					TestResourceAndMethod{Resource: "/services/metrics/v2/schemas/schema", Method: "GET"}: getRoute(&MetricsSchema{
+						Id:   &schemaId,
+						Name: "deployments",
+						Attributes: []MetricsSchemaAttribute{
+							{Name: "environment", Type: "dimension"},
+							{Name: "region", Type: "dimension"},
+							{Name: "count", Type: "metric"},
+						},
+					}),
+					TestResourceAndMethod{Resource: "/services/metrics/v2/schemas/schema/points", Method: "GET"}: getRoute([]MetricsPoint{testCase.point}),
+				},
+			)
+			defer testServer.Close()
+
+			d := schema.TestResourceDataRaw(t, resourceLeanixMetricsPoint().Schema, map[string]interface{}{
+				"schema_id":  "schema",
+				"timestamp":  "2024-01-01T00:00:00Z",
+				"dimensions": map[string]interface{}{"environment": "prod"},
+				"metrics":    map[string]interface{}{"count": 3},
+			})
+			d.SetId("schema/2024-01-01T00:00:00Z/environment=prod")
+			err := resourceLeanixMetricsPointRead(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+			if err != nil {
+				t.Fatalf("resourceLeanixMetricsPointRead() returned an error: %s", err)
+			}
+			assertEqual(t, d.Id() != "", testCase.expectedFound)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package leanix
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+// Metrics schemas cannot be changed once they contain points, so every change creates a new schema.
+func resourceLeanixMetricsSchema() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixMetricsSchemaCreate,
+		Read:   resourceLeanixMetricsSchemaRead,
+		Delete: resourceLeanixMetricsSchemaDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"attribute": &schema.Schema{
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice(metricsSchemaAttributeTypes, false),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceLeanixMetricsSchemaCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	metricsSchema := MetricsSchema{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Attributes:  extractMetricsSchemaAttributes(d.Get("attribute")),
+	}
+	created, err := leanixClient.CreateMetricsSchema(metricsSchema)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*created.Id)
+	return resourceLeanixMetricsSchemaRead(d, meta)
+}
+
+func resourceLeanixMetricsSchemaRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	schemaId := d.Id()
+	if schemaId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	metricsSchema, err := leanixClient.ReadMetricsSchema(schemaId)
+	if err != nil {
+		return err
+	}
+	if metricsSchema == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", metricsSchema.Name)
+	d.Set("description", metricsSchema.Description)
+	d.Set("attribute", packageMetricsSchemaAttributes(metricsSchema.Attributes))
+
+	return nil
+}
+
+func resourceLeanixMetricsSchemaDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	schemaId := d.Id()
+	if schemaId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteMetricsSchema(schemaId)
+}
+
+func extractMetricsSchemaAttributes(value interface{}) []MetricsSchemaAttribute {
+	attributes := []MetricsSchemaAttribute{}
+	for _, rawAttribute := range value.([]interface{}) {
+		attribute := rawAttribute.(map[string]interface{})
+		attributes = append(attributes, MetricsSchemaAttribute{
+			Name: attribute["name"].(string),
+			Type: attribute["type"].(string),
+		})
+	}
+	return attributes
+}
+
+func packageMetricsSchemaAttributes(attributes []MetricsSchemaAttribute) []map[string]string {
+	packagedAttributes := []map[string]string{}
+	for _, attribute := range attributes {
+		packagedAttributes = append(packagedAttributes, map[string]string{
+			"name": attribute.Name,
+			"type": attribute.Type,
+		})
+	}
+	return packagedAttributes
+}
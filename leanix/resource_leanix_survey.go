@@ -0,0 +1,139 @@
+package leanix
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixSurvey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixSurveyCreate,
+		Read:   resourceLeanixSurveyRead,
+		Update: resourceLeanixSurveyUpdate,
+		Delete: resourceLeanixSurveyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"questionnaire": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The questions of the survey as JSON object.",
+				ValidateFunc: validation.ValidateJsonString,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+			"fact_sheet_filter": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The filter selecting the fact sheets the survey is about as JSON object.",
+				ValidateFunc: validation.ValidateJsonString,
+				StateFunc:    normalizeJsonStateFunc,
+			},
+			"recipient_subscription_types": &schema.Schema{
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The subscribers of the selected fact sheets with these subscription types receive the survey.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"RESPONSIBLE", "ACCOUNTABLE", "OBSERVER"}, false),
+				},
+			},
+			"recipient_subscription_roles": &schema.Schema{
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Restricts the recipients to subscribers with one of these subscription roles.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceLeanixSurveyCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	created, err := leanixClient.CreatePoll(extractPoll(d))
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*created.Id)
+	return resourceLeanixSurveyRead(d, meta)
+}
+
+func resourceLeanixSurveyRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	pollId := d.Id()
+	if pollId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	poll, err := leanixClient.ReadPoll(pollId)
+	if err != nil {
+		return err
+	}
+	if poll == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("title", poll.Title)
+	// only the configured keys, LeanIX adds defaults and IDs to both
+	d.Set("questionnaire", projectJsonDocument(string(poll.Questionnaire), d.Get("questionnaire").(string)))
+	d.Set("fact_sheet_filter", projectJsonDocument(string(poll.FactSheetFilter), d.Get("fact_sheet_filter").(string)))
+	d.Set("recipient_subscription_types", poll.Recipients.SubscriptionTypes)
+	d.Set("recipient_subscription_roles", poll.Recipients.SubscriptionRoles)
+
+	return nil
+}
+
+func resourceLeanixSurveyUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	pollId := d.Id()
+	if pollId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	poll := extractPoll(d)
+	poll.Id = &pollId
+	_, err := leanixClient.UpdatePoll(poll)
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixSurveyRead(d, meta)
+}
+
+func resourceLeanixSurveyDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	pollId := d.Id()
+	if pollId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeletePoll(pollId)
+}
+
+func extractPoll(d *schema.ResourceData) Poll {
+	return Poll{
+		Title:           d.Get("title").(string),
+		Questionnaire:   json.RawMessage(d.Get("questionnaire").(string)),
+		FactSheetFilter: json.RawMessage(d.Get("fact_sheet_filter").(string)),
+		Recipients: PollRecipients{
+			SubscriptionTypes: extractSetStrings(d.Get("recipient_subscription_types").(*schema.Set)),
+			SubscriptionRoles: extractSetStrings(d.Get("recipient_subscription_roles").(*schema.Set)),
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package leanix
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceLeanixSurveyRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixSurveyRunCreate,
+		Read:   resourceLeanixSurveyRunRead,
+		Update: resourceLeanixSurveyRunUpdate,
+		Delete: resourceLeanixSurveyRunDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"survey_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"title": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"due_date": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The date until which the recipients should answer, e.g. 2026-12-31.",
+				ValidateFunc: validatePollRunDueDate,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixSurveyRunCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	created, err := leanixClient.CreatePollRun(PollRun{
+		PollId:  d.Get("survey_id").(string),
+		Title:   d.Get("title").(string),
+		DueDate: d.Get("due_date").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*created.Id)
+	return resourceLeanixSurveyRunRead(d, meta)
+}
+
+func resourceLeanixSurveyRunRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	pollRunId := d.Id()
+	if pollRunId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	pollRun, err := leanixClient.ReadPollRun(pollRunId)
+	if err != nil {
+		return err
+	}
+	if pollRun == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("survey_id", pollRun.PollId)
+	d.Set("title", pollRun.Title)
+	d.Set("due_date", pollRun.DueDate)
+	d.Set("status", pollRun.Status)
+
+	return nil
+}
+
+func resourceLeanixSurveyRunUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	pollRunId := d.Id()
+	if pollRunId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	_, err := leanixClient.UpdatePollRun(PollRun{
+		Id:      &pollRunId,
+		PollId:  d.Get("survey_id").(string),
+		Title:   d.Get("title").(string),
+		DueDate: d.Get("due_date").(string),
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceLeanixSurveyRunRead(d, meta)
+}
+
+func resourceLeanixSurveyRunDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	pollRunId := d.Id()
+	if pollRunId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeletePollRun(pollRunId)
+}
@@ -0,0 +1,44 @@
+package leanix
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func TestResourceLeanixSurveyReadConfiguredKeys(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/poll/v2/polls/poll", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{"Authorization": authHeader},
+				ExpectedBody:   []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusOK
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte(`{"status":"OK","data":{"id":"poll","title":"Owners","questionnaire":{"questions":[{"id":"q1","type":"text","label":"Owner?","mandatory":false}],"version":2},"factSheetFilter":{"facetFilter":[],"fullTextSearchTerm":"","type":"Application"},"recipients":{"subscriptionTypes":["RESPONSIBLE"],"subscriptionRoles":[]}}}`)
+				},
+			},
+		},
+	)
+	defer testServer.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceLeanixSurvey().Schema, map[string]interface{}{
+		"title":                        "Owners",
+		"questionnaire":                `{"questions": [{"type": "text", "label": "Owner?"}]}`,
+		"fact_sheet_filter":            `{"type": "Application"}`,
+		"recipient_subscription_types": []interface{}{"RESPONSIBLE"},
+	})
+	d.SetId("poll")
+	err := resourceLeanixSurveyRead(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+	if err != nil {
+		t.Fatalf("resourceLeanixSurveyRead() returned an error: %s", err)
+	}
+	assertEqual(t, d.Get("questionnaire"), `{"questions":[{"label":"Owner?","type":"text"}]}`)
+	assertEqual(t, d.Get("fact_sheet_filter"), `{"type":"Application"}`)
+}
@@ -0,0 +1,169 @@
+package leanix
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixTechnicalUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixTechnicalUserCreate,
+		Read:   resourceLeanixTechnicalUserRead,
+		Update: resourceLeanixTechnicalUserUpdate,
+		Delete: resourceLeanixTechnicalUserDelete,
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			// a new token is generated on rotation, so it is unknown until the apply
+			if d.Id() != "" && d.HasChange("rotation_trigger") {
+				return d.SetNewComputed("api_token")
+			}
+			return nil
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"workspace_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "MEMBER",
+				ValidateFunc: validation.StringInSlice([]string{"ADMIN", "MEMBER", "VIEWER"}, false),
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"expiry": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Expiry date of the API token as RFC 3339 timestamp. The token never expires if this is not set.",
+				ValidateFunc: validation.ValidateRFC3339TimeString,
+				StateFunc: func(value interface{}) string {
+					return normalizeTechnicalUserExpiry(value.(string))
+				},
+			},
+			"rotation_trigger": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Arbitrary value. Changing it replaces the API token of the technical user.",
+			},
+			"api_token": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixTechnicalUserCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	technicalUser := TechnicalUser{
+		UserName:    d.Get("user_name").(string),
+		Description: d.Get("description").(string),
+		Role:        d.Get("role").(string),
+		WorkspaceId: d.Get("workspace_id").(string),
+		Expiry:      normalizeTechnicalUserExpiry(d.Get("expiry").(string)),
+	}
+	created, err := leanixClient.CreateTechnicalUser(technicalUser)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*created.Id)
+	d.Set("api_token", created.ApiToken)
+	return nil
+}
+
+func resourceLeanixTechnicalUserRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	technicalUserId := d.Id()
+	if technicalUserId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	technicalUser, err := leanixClient.ReadTechnicalUser(technicalUserId)
+	if err != nil {
+		return err
+	}
+	if technicalUser == nil {
+		d.SetId("")
+		return nil
+	}
+
+	// the API token is only returned on creation and rotation, so we keep the one from the state
+	d.Set("user_name", technicalUser.UserName)
+	d.Set("description", technicalUser.Description)
+	d.Set("role", technicalUser.Role)
+	d.Set("workspace_id", technicalUser.WorkspaceId)
+	d.Set("expiry", normalizeTechnicalUserExpiry(technicalUser.Expiry))
+
+	return nil
+}
+
+func resourceLeanixTechnicalUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	technicalUserId := d.Id()
+	if technicalUserId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	// Only the parts which succeeded are stored if an update fails. Otherwise e.g.
+	// a new rotation trigger would be stored although the token wasn't replaced,
+	// and the rotation would never be retried.
+	d.Partial(true)
+
+	if d.HasChange("role") || d.HasChange("description") || d.HasChange("expiry") {
+		technicalUser := TechnicalUser{
+			Id:          &technicalUserId,
+			UserName:    d.Get("user_name").(string),
+			Description: d.Get("description").(string),
+			Role:        d.Get("role").(string),
+			WorkspaceId: d.Get("workspace_id").(string),
+			Expiry:      normalizeTechnicalUserExpiry(d.Get("expiry").(string)),
+		}
+		_, err := leanixClient.UpdateTechnicalUser(technicalUser)
+		if err != nil {
+			return err
+		}
+		d.SetPartial("role")
+		d.SetPartial("description")
+		d.SetPartial("expiry")
+	}
+
+	if d.HasChange("rotation_trigger") {
+		rotated, err := leanixClient.ReplaceTechnicalUserApiToken(technicalUserId)
+		if err != nil {
+			return err
+		}
+		d.Set("api_token", rotated.ApiToken)
+		d.SetPartial("api_token")
+		d.SetPartial("rotation_trigger")
+	}
+
+	d.Partial(false)
+	return nil
+}
+
+func resourceLeanixTechnicalUserDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	technicalUserId := d.Id()
+	if technicalUserId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.DeleteTechnicalUser(technicalUserId)
+}
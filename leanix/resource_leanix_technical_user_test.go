@@ -0,0 +1,79 @@
+package leanix
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceLeanixTechnicalUserFailedRotation(t *testing.T) {
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	route := func(expectedBody string, status int, response string) *TestRouteDefinition {
+		return &TestRouteDefinition{
+			ExpectedHeader: map[string]string{"Authorization": authHeader},
+			ExpectedBody:   []byte(expectedBody),
+			ResponseStatus: func(header http.Header, body []byte) int {
+				return status
+			},
+			ResponseBody: func(header http.Header, body []byte) []byte {
+				return []byte(response)
+			},
+		}
+	}
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/mtm/v1/technicalUsers/user", Method: "PUT"}: route(
+				`{"id":"user","userName":"ci","role":"ADMIN","workspaceId":"workspace","expiry":"2026-12-31T00:00:00Z"}`,
+				http.StatusOK,
+				`{"status":"OK","data":{"id":"user","userName":"ci","role":"ADMIN","workspaceId":"workspace","expiry":"2026-12-31T00:00:00.000+0000"}}`,
+			),
+			TestResourceAndMethod{Resource: "/services/mtm/v1/technicalUsers/user/replaceApiToken", Method: "POST"}: route(
+				"",
+				http.StatusInternalServerError,
+				`{"status":"ERROR","errors":[{"value":"Internal error"}]}`,
+			),
+		},
+	)
+	defer testServer.Close()
+
+	resource := resourceLeanixTechnicalUser()
+	state := &terraform.InstanceState{
+		ID: "user",
+		Attributes: map[string]string{
+			"id":               "user",
+			"user_name":        "ci",
+			"workspace_id":     "workspace",
+			"role":             "MEMBER",
+			"expiry":           "2026-12-31T00:00:00Z",
+			"rotation_trigger": "1",
+			"api_token":        "old-token",
+		},
+	}
+	client := NewLeanixClient(testServer.URL, leanixBasicAuthHeader)
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"user_name":        "ci",
+		"workspace_id":     "workspace",
+		"role":             "ADMIN",
+		"expiry":           "2026-12-31T01:00:00+01:00",
+		"rotation_trigger": "2",
+	}), client)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %s", err)
+	}
+	if _, ok := diff.Attributes["expiry"]; ok {
+		t.Fatalf("Expected the same expiry in another time zone not to change, but got %v", diff.Attributes["expiry"])
+	}
+
+	newState, err := resource.Apply(state, diff, client)
+	if err == nil {
+		t.Fatal("Expected the failed rotation to be reported")
+	}
+	// the role was updated, but the rotation must be retried with the next apply
+	assertEqual(t, newState.Attributes["role"], "ADMIN")
+	assertEqual(t, newState.Attributes["rotation_trigger"], "1")
+	assertEqual(t, newState.Attributes["api_token"], "old-token")
+}
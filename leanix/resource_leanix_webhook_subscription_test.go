@@ -3,7 +3,6 @@ package leanix
 import (
 	"encoding/base64"
 	"fmt"
-	"reflect"
 	"strings"
 	"testing"
 
@@ -100,13 +99,52 @@ func testCheckSubscriptionResourceValues(subscription *WebhookSubscription, reso
 			[]string{"pathfinder", "FACT_SHEET_UPDATED"},
 			[]string{"pathfinder", "FACT_SHEET_ARCHIVED"},
 		}
-		if !reflect.DeepEqual(subscription.TagSets, expectedTagSets) {
+		if !sameTagSets(subscription.TagSets, expectedTagSets) {
 			return fmt.Errorf("Bad subscription.TagSets, expected \"%s\", got: %#v", expectedTagSets, subscription.TagSets)
 		}
 		return nil
 	}
 }
 
+// sameTagSets compares tag sets as sets of sets, like the schema does, so the
+// order of the tag sets and of their tags doesn't matter.
+func sameTagSets(actual [][]string, expected [][]string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	matched := make([]bool, len(actual))
+	for _, expectedTagSet := range expected {
+		found := false
+		for index, actualTagSet := range actual {
+			if !matched[index] && sameTags(actualTagSet, expectedTagSet) {
+				matched[index] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func sameTags(actual []string, expected []string) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	tags := map[string]bool{}
+	for _, tag := range actual {
+		tags[tag] = true
+	}
+	for _, tag := range expected {
+		if !tags[tag] {
+			return false
+		}
+	}
+	return true
+}
+
 // testCheckResourceExists queries the API and retrieves the matching subscription.
 func testCheckResourceExists(target string, subscription *WebhookSubscription) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
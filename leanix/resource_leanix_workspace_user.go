@@ -0,0 +1,142 @@
+package leanix
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+)
+
+func resourceLeanixWorkspaceUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceLeanixWorkspaceUserCreate,
+		Read:   resourceLeanixWorkspaceUserRead,
+		Update: resourceLeanixWorkspaceUserUpdate,
+		Delete: resourceLeanixWorkspaceUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"workspace_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"email": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				// LeanIX may return the address with another case than configured
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
+			},
+			"role": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ADMIN", "MEMBER", "VIEWER"}, false),
+			},
+			"invitation_message": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Personal message included in the invitation email. Only used when the user is invited.",
+			},
+			"user_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceLeanixWorkspaceUserCreate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	invitation := WorkspaceInvitation{
+		User: WorkspaceUser{
+			Email: d.Get("email").(string),
+		},
+		Permission: WorkspacePermission{
+			WorkspaceId: d.Get("workspace_id").(string),
+			Role:        d.Get("role").(string),
+		},
+		Message: d.Get("invitation_message").(string),
+	}
+	permission, err := leanixClient.InviteWorkspaceUser(invitation)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(*permission.Id)
+	return resourceLeanixWorkspaceUserRead(d, meta)
+}
+
+func resourceLeanixWorkspaceUserRead(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	permissionId := d.Id()
+	if permissionId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot read resource!")
+	}
+
+	permission, err := leanixClient.ReadWorkspacePermission(permissionId)
+	if err != nil {
+		return err
+	}
+	if permission == nil || permission.Status == "ARCHIVED" {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("workspace_id", permission.WorkspaceId)
+	d.Set("email", permission.User.Email)
+	d.Set("role", permission.Role)
+	d.Set("user_id", permission.User.Id)
+	d.Set("status", permission.Status)
+
+	return nil
+}
+
+func resourceLeanixWorkspaceUserUpdate(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	permissionId := d.Id()
+	if permissionId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot update resource!")
+	}
+
+	if d.HasChange("role") {
+		permission, err := leanixClient.ReadWorkspacePermission(permissionId)
+		if err != nil {
+			return err
+		}
+		if permission == nil {
+			return errors.New("Permission '" + permissionId + "' does not exist anymore. Maybe it was removed outside of Terraform?")
+		}
+
+		permission.Role = d.Get("role").(string)
+		_, err = leanixClient.UpdateWorkspacePermission(*permission)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceLeanixWorkspaceUserRead(d, meta)
+}
+
+func resourceLeanixWorkspaceUserDelete(d *schema.ResourceData, meta interface{}) error {
+	leanixClient := meta.(*LeanixClient)
+
+	permissionId := d.Id()
+	if permissionId == "" {
+		return errors.New("Terraform internal resource ID not set. Cannot delete resource!")
+	}
+
+	return leanixClient.ArchiveWorkspacePermission(permissionId)
+}
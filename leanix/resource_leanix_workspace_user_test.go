@@ -0,0 +1,42 @@
+package leanix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestResourceLeanixWorkspaceUserEmailCase(t *testing.T) {
+	resource := resourceLeanixWorkspaceUser()
+	state := &terraform.InstanceState{
+		ID: "permission",
+		Attributes: map[string]string{
+			"id":           "permission",
+			"workspace_id": "workspace",
+			"email":        "Jane.Doe@Example.com",
+			"role":         "MEMBER",
+		},
+	}
+
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id": "workspace",
+		"email":        "jane.doe@example.com",
+		"role":         "MEMBER",
+	}), nil)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %s", err)
+	}
+	if !diff.Empty() {
+		t.Fatalf("Expected no changes for another case of the email, but got %v", diff.Attributes)
+	}
+
+	diff, err = resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"workspace_id": "workspace",
+		"email":        "john.doe@example.com",
+		"role":         "MEMBER",
+	}), nil)
+	if err != nil {
+		t.Fatalf("Diff returned an error: %s", err)
+	}
+	assertEqual(t, diff.RequiresNew(), true)
+}
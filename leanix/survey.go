@@ -0,0 +1,53 @@
+package leanix
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Surveys are called polls in the LeanIX API.
+type Poll struct {
+	Id              *string         `json:"id,omitempty"`
+	Title           string          `json:"title"`
+	Questionnaire   json.RawMessage `json:"questionnaire"`
+	FactSheetFilter json.RawMessage `json:"factSheetFilter"`
+	Recipients      PollRecipients  `json:"recipients"`
+}
+
+// The recipients of a poll are the subscribers of the targeted fact sheets.
+type PollRecipients struct {
+	SubscriptionTypes []string `json:"subscriptionTypes"`
+	SubscriptionRoles []string `json:"subscriptionRoles"`
+}
+
+type PollResponse struct {
+	Status string `json:"status"`
+	Poll   *Poll  `json:"data"`
+}
+
+type PollRun struct {
+	Id      *string `json:"id,omitempty"`
+	PollId  string  `json:"pollId"`
+	Title   string  `json:"title"`
+	DueDate string  `json:"dueDate"`
+	Status  string  `json:"status,omitempty"`
+}
+
+type PollRunResponse struct {
+	Status  string   `json:"status"`
+	PollRun *PollRun `json:"data"`
+}
+
+const pollRunDueDateLayout = "2006-01-02"
+
+func validatePollRunDueDate(i interface{}, k string) ([]string, []error) {
+	value, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+	if _, err := time.Parse(pollRunDueDateLayout, value); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a date of the form YYYY-MM-DD: %s", k, value)}
+	}
+	return nil, nil
+}
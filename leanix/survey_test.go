@@ -0,0 +1,18 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestValidatePollRunDueDate(t *testing.T) {
+	for _, dueDate := range []string{"2026-12-31", "2024-02-29"} {
+		if _, errs := validatePollRunDueDate(dueDate, "due_date"); len(errs) != 0 {
+			t.Fatalf("Expected %s to be a valid due date, got: %v", dueDate, errs)
+		}
+	}
+	for _, dueDate := range []string{"", "31.12.2026", "2026-12-31T00:00:00Z", "2025-02-29"} {
+		if _, errs := validatePollRunDueDate(dueDate, "due_date"); len(errs) == 0 {
+			t.Fatalf("Expected %q to be an invalid due date", dueDate)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package leanix
+
+import (
+	"time"
+)
+
+type TechnicalUser struct {
+	Id          *string `json:"id,omitempty"`
+	UserName    string  `json:"userName"`
+	Description string  `json:"description,omitempty"`
+	Role        string  `json:"role"`
+	WorkspaceId string  `json:"workspaceId"`
+	Expiry      string  `json:"expiry,omitempty"`
+	ApiToken    string  `json:"apiToken,omitempty"`
+}
+
+// LeanIX returns the expiry with milliseconds and a numeric offset, e.g.
+// 2026-12-31T00:00:00.000+0000, so it is stored as RFC 3339 timestamp in UTC
+// for the state to match the configuration. Values which can't be parsed are
+// kept as they are.
+func normalizeTechnicalUserExpiry(expiry string) string {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700"} {
+		parsed, err := time.Parse(layout, expiry)
+		if err == nil {
+			return parsed.UTC().Format(time.RFC3339)
+		}
+	}
+	return expiry
+}
@@ -0,0 +1,13 @@
+package leanix
+
+import (
+	"testing"
+)
+
+func TestNormalizeTechnicalUserExpiry(t *testing.T) {
+	assertEqual(t, normalizeTechnicalUserExpiry("2026-12-31T00:00:00Z"), "2026-12-31T00:00:00Z")
+	assertEqual(t, normalizeTechnicalUserExpiry("2026-12-31T00:00:00.000+0000"), "2026-12-31T00:00:00Z")
+	assertEqual(t, normalizeTechnicalUserExpiry("2026-12-31T01:00:00+01:00"), "2026-12-31T00:00:00Z")
+	assertEqual(t, normalizeTechnicalUserExpiry(""), "")
+	assertEqual(t, normalizeTechnicalUserExpiry("next year"), "next year")
+}
@@ -4,8 +4,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
+
+	"github.com/codecentric/terraform-provider-leanix/leanix/cassette"
 )
 
 func assertEqual(t *testing.T, actual interface{}, expected interface{}) {
@@ -19,6 +26,9 @@ type TestRoute map[TestResourceAndMethod]*TestRouteDefinition
 type TestRouteDefinition struct {
 	ExpectedHeader map[string]string
 	ExpectedBody   []byte
+	// Checks the body instead of ExpectedBody, for bodies which differ from
+	// request to request, e.g. multipart forms with their random boundary.
+	MatchBody      func(http.Header, []byte) error
 	ResponseStatus func(http.Header, []byte) int
 	ResponseBody   func(http.Header, []byte) []byte
 }
@@ -28,29 +38,141 @@ type TestResourceAndMethod struct {
 	Method   string
 }
 
+// Faults make the test server misbehave like LeanIX under load or during an
+// outage, to cover the retry and error handling of the client.
+type TestFaults struct {
+	// Delay every response.
+	Latency time.Duration
+	// Answer the first FailureCount requests of each route with FailureStatus,
+	// e.g. 429 or 503, before serving them normally. RetryAfter is sent as
+	// Retry-After header with the failures if set.
+	FailureStatus int
+	FailureCount  int
+	RetryAfter    string
+	// Cut the response body of the first TruncatedCount requests of each route
+	// in half, so the connection breaks before the response is complete.
+	// Token requests are never truncated.
+	TruncatedCount int
+	// Answer every request except token requests with a body which is no
+	// valid JSON.
+	MalformedJSON bool
+	// Reject an access token with 401 after it has been used this many times,
+	// like LeanIX does with expired tokens, until a new token is requested.
+	TokenExpiresAfter int
+}
+
 func NewTestServer(t *testing.T, route TestRoute) *httptest.Server {
+	return NewFaultyTestServer(t, route, TestFaults{})
+}
+
+// Unexpected requests are reported as test errors and answered with 404 or
+// 400, as the handler doesn't run in the test goroutine and can't stop it.
+func NewFaultyTestServer(t *testing.T, route TestRoute, faults TestFaults) *httptest.Server {
+	var lock sync.Mutex
+	requestCounts := map[TestResourceAndMethod]int{}
+	tokenUses := 0
+
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		requestRoute := TestResourceAndMethod{r.URL.EscapedPath(), r.Method}
 		matchingRoute := route[requestRoute]
 		if matchingRoute == nil {
-			t.Fatalf("No route matches '%s'", requestRoute)
+			t.Errorf("No route matches '%s'", requestRoute)
+			http.Error(w, "No route matches", http.StatusNotFound)
+			return
 		}
 
 		for k, v := range matchingRoute.ExpectedHeader {
 			if r.Header.Get(k) != v {
-				t.Fatalf("Expected header '%s:%s'", k, v)
+				t.Errorf("Expected header '%s:%s'", k, v)
+				http.Error(w, "Unexpected header", http.StatusBadRequest)
+				return
 			}
 		}
 
 		defer r.Body.Close()
 		bodyBytes, err := ioutil.ReadAll(r.Body)
 		if err != nil {
-			t.Fatal(err)
+			t.Error(err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if matchingRoute.MatchBody != nil {
+			if err := matchingRoute.MatchBody(r.Header, bodyBytes); err != nil {
+				t.Errorf("Unexpected body: %s", err)
+				http.Error(w, "Unexpected body", http.StatusBadRequest)
+				return
+			}
+		} else if !reflect.DeepEqual(bodyBytes, matchingRoute.ExpectedBody) {
+			t.Errorf("Expected body %s to be equal to %s", matchingRoute.ExpectedBody, bodyBytes)
+			http.Error(w, "Unexpected body", http.StatusBadRequest)
+			return
+		}
+
+		lock.Lock()
+		requestCount := requestCounts[requestRoute]
+		requestCounts[requestRoute]++
+		isTokenRequest := requestRoute.Resource == "/services/mtm/v1/oauth2/token"
+		tokenExpired := false
+		if isTokenRequest {
+			tokenUses = 0
+		} else {
+			tokenUses++
+			tokenExpired = faults.TokenExpiresAfter > 0 && tokenUses > faults.TokenExpiresAfter
+		}
+		lock.Unlock()
+
+		time.Sleep(faults.Latency)
+		if tokenExpired {
+			http.Error(w, `{"status":"ERROR","errors":[{"value":"Token expired"}]}`, http.StatusUnauthorized)
+			return
+		}
+		if requestCount < faults.FailureCount {
+			if faults.RetryAfter != "" {
+				w.Header().Set("Retry-After", faults.RetryAfter)
+			}
+			http.Error(w, http.StatusText(faults.FailureStatus), faults.FailureStatus)
+			return
 		}
-		assertEqual(t, bodyBytes, matchingRoute.ExpectedBody)
 
+		responseBody := matchingRoute.ResponseBody(r.Header, bodyBytes)
+		if faults.MalformedJSON && !isTokenRequest {
+			responseBody = []byte(`{"status": "OK", "data": `)
+		}
+		if requestCount < faults.TruncatedCount && !isTokenRequest {
+			w.Header().Set("Content-Length", strconv.Itoa(len(responseBody)))
+			responseBody = responseBody[:len(responseBody)/2]
+		}
 		w.WriteHeader(matchingRoute.ResponseStatus(r.Header, bodyBytes))
-		w.Write(matchingRoute.ResponseBody(r.Header, bodyBytes))
+		w.Write(responseBody)
 	})
 	return httptest.NewServer(handler)
 }
+
+// Create a client replaying the cassette testdata/cassettes/<name>.json.
+// With LEANIX_RECORD_CASSETTES set the cassette is recorded instead, against
+// the LeanIX instance configured by LEANIX_URL and LEANIX_AUTH_HEADER.
+func NewCassetteClient(t *testing.T, name string) *LeanixClient {
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	mode := cassette.ModeReplay
+	url := "https://leanix.invalid"
+	authHeader := "Basic cmVwbGF5ZWQ="
+	if os.Getenv("LEANIX_RECORD_CASSETTES") != "" {
+		mode = cassette.ModeRecord
+		url = os.Getenv("LEANIX_URL")
+		authHeader = os.Getenv("LEANIX_AUTH_HEADER")
+		if url == "" || authHeader == "" {
+			t.Fatal("LEANIX_URL and LEANIX_AUTH_HEADER must be set to record cassettes")
+		}
+	}
+
+	recorder, err := cassette.New(path, mode, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := recorder.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	return NewLeanixClientWithTransport(url, authHeader, recorder)
+}
@@ -0,0 +1,195 @@
+package leanix
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/codecentric/terraform-provider-leanix/leanix"
+
+var (
+	tracingOnce    sync.Once
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// Export spans via OTLP if an endpoint is set with the standard OpenTelemetry
+// environment variables, which also configure the protocol details.
+// Otherwise the global no-op tracer provider stays in place.
+func configureTracing() {
+	tracingOnce.Do(func() {
+		if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+			return
+		}
+
+		ctx := context.Background()
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			log.Printf("[WARN] Cannot export traces via OTLP: %s", err)
+			return
+		}
+		// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the defaults
+		resource, err := sdkresource.New(
+			ctx,
+			sdkresource.WithAttributes(semconv.ServiceName("terraform-provider-leanix")),
+			sdkresource.WithTelemetrySDK(),
+			sdkresource.WithFromEnv(),
+		)
+		if err != nil {
+			log.Printf("[WARN] Cannot detect all attributes of the traces: %s", err)
+		}
+		tracerProvider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithResource(resource),
+		)
+		otel.SetTracerProvider(tracerProvider)
+	})
+}
+
+// Export the spans ended so far. Terraform stops the provider without notice,
+// so spans are flushed after each operation instead of on shutdown.
+func flushTraces() {
+	if tracerProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracerProvider.ForceFlush(ctx); err != nil {
+		log.Printf("[WARN] Failed to export traces: %s", err)
+	}
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Continue the trace of the pipeline step running Terraform if it passes its
+// trace context in the TRACEPARENT and TRACESTATE environment variables.
+func parentTraceContext() context.Context {
+	carrier := propagation.MapCarrier{
+		"traceparent": os.Getenv("TRACEPARENT"),
+		"tracestate":  os.Getenv("TRACESTATE"),
+	}
+	return propagation.TraceContext{}.Extract(context.Background(), carrier)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Trace the functions of a resource or data source with a span per operation.
+// The client passed to them sends its requests in the context of the span, so
+// the requests show up as its children.
+func withTracing(name string, resource *schema.Resource, isDataSource bool) *schema.Resource {
+	spanName := name
+	if isDataSource {
+		spanName = "data." + name
+	}
+
+	resource.Create = tracedCrudFunc(spanName, name, "create", resource.Create)
+	resource.Read = tracedCrudFunc(spanName, name, "read", resource.Read)
+	resource.Update = tracedCrudFunc(spanName, name, "update", resource.Update)
+	resource.Delete = tracedCrudFunc(spanName, name, "delete", resource.Delete)
+	if resource.Importer != nil && resource.Importer.State != nil {
+		importer := resource.Importer.State
+		resource.Importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			ctx, span := startOperationSpan(spanName, name, "import")
+			result, err := importer(d, clientWithContext(meta, ctx))
+			span.SetAttributes(attribute.String("leanix.resource_id", d.Id()))
+			endSpan(span, err)
+			flushTraces()
+			return result, err
+		}
+	}
+	return resource
+}
+
+func tracedCrudFunc(spanName string, name string, operation string, crudFunc func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if crudFunc == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		ctx, span := startOperationSpan(spanName, name, operation)
+		if workspace, ok := d.GetOk("workspace"); ok {
+			span.SetAttributes(attribute.String("leanix.workspace", workspace.(string)))
+		}
+		err := crudFunc(d, clientWithContext(meta, ctx))
+		span.SetAttributes(attribute.String("leanix.resource_id", d.Id()))
+		endSpan(span, err)
+		flushTraces()
+		return err
+	}
+}
+
+func startOperationSpan(spanName string, name string, operation string) (context.Context, trace.Span) {
+	return tracer().Start(
+		parentTraceContext(),
+		spanName+"."+operation,
+		trace.WithAttributes(
+			attribute.String("leanix.resource_type", name),
+			attribute.String("leanix.operation", operation),
+		),
+	)
+}
+
+func clientWithContext(meta interface{}, ctx context.Context) interface{} {
+	if client, ok := meta.(*LeanixClient); ok {
+		return client.withContext(ctx)
+	}
+	return meta
+}
+
+// Transport recording a client span for every request sent to LeanIX, including
+// token requests and retries. Only the path is recorded, as query parameters
+// may contain personal data like email addresses.
+type tracingTransport struct {
+	transport http.RoundTripper
+}
+
+func newTracingTransport(transport http.RoundTripper) http.RoundTripper {
+	return &tracingTransport{transport: transport}
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := tracer().Start(
+		req.Context(),
+		"LeanIX "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLPath(req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.transport.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,138 @@
+package leanix
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// Record the spans of a test in memory instead of exporting them.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) attribute.Value {
+	for _, keyValue := range span.Attributes() {
+		if keyValue.Key == key {
+			return keyValue.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestWithTracing(t *testing.T) {
+	recorder := recordSpans(t)
+
+	apiToken, leanixBasicAuthHeader := apiTokenAndLeanixBasicAuthHeader()
+	authRoute, authHeader := NewAuthRouteDefinition(t, apiToken)
+	testServer := NewTestServer(
+		t,
+		TestRoute{
+			TestResourceAndMethod{Resource: "/services/mtm/v1/oauth2/token", Method: "POST"}: authRoute,
+			TestResourceAndMethod{Resource: "/services/webhooks/v1/subscriptions/id", Method: "GET"}: &TestRouteDefinition{
+				ExpectedHeader: map[string]string{
+					"Authorization": authHeader,
+				},
+				ExpectedBody: []byte{},
+				ResponseStatus: func(header http.Header, body []byte) int {
+					return http.StatusNotFound
+				},
+				ResponseBody: func(header http.Header, body []byte) []byte {
+					return []byte{}
+				},
+			},
+		},
+	)
+	defer testServer.Close()
+
+	resource := withTracing("leanix_test", &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			status, _, err := meta.(*LeanixClient).doJSONRequest("GET", "/services/webhooks/v1/subscriptions/"+d.Id(), nil)
+			if err != nil {
+				return err
+			}
+			if status != http.StatusOK {
+				return errors.New("not found")
+			}
+			return nil
+		},
+	}, false)
+	d := resource.TestResourceData()
+	d.SetId("id")
+	err := resource.Read(d, NewLeanixClient(testServer.URL, leanixBasicAuthHeader))
+	if err == nil {
+		t.Fatal("Expected the error of the read function to be returned")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("Expected spans for the token request, the request and the operation, but got %d", len(spans))
+	}
+	tokenSpan, requestSpan, operationSpan := spans[0], spans[1], spans[2]
+
+	assertEqual(t, operationSpan.Name(), "leanix_test.read")
+	assertEqual(t, spanAttribute(operationSpan, "leanix.resource_type").AsString(), "leanix_test")
+	assertEqual(t, spanAttribute(operationSpan, "leanix.operation").AsString(), "read")
+	assertEqual(t, spanAttribute(operationSpan, "leanix.resource_id").AsString(), "id")
+	assertEqual(t, operationSpan.Status().Code, codes.Error)
+
+	for _, span := range []sdktrace.ReadOnlySpan{tokenSpan, requestSpan} {
+		assertEqual(t, span.Parent().SpanID(), operationSpan.SpanContext().SpanID())
+		assertEqual(t, span.SpanContext().TraceID(), operationSpan.SpanContext().TraceID())
+	}
+	assertEqual(t, tokenSpan.Name(), "LeanIX POST")
+	assertEqual(t, spanAttribute(tokenSpan, "http.response.status_code").AsInt64(), int64(http.StatusOK))
+	assertEqual(t, tokenSpan.Status().Code, codes.Unset)
+	assertEqual(t, requestSpan.Name(), "LeanIX GET")
+	assertEqual(t, spanAttribute(requestSpan, "url.path").AsString(), "/services/webhooks/v1/subscriptions/id")
+	assertEqual(t, spanAttribute(requestSpan, "http.response.status_code").AsInt64(), int64(http.StatusNotFound))
+	assertEqual(t, requestSpan.Status().Code, codes.Error)
+}
+
+func TestWithTracingContinuesParentTrace(t *testing.T) {
+	recorder := recordSpans(t)
+	t.Setenv("TRACEPARENT", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	resource := withTracing("leanix_test", &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			return nil
+		},
+	}, true)
+	err := resource.Read(resource.TestResourceData(), nil)
+	if err != nil {
+		t.Fatalf("Read returned an error: %s", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected one span, but got %d", len(spans))
+	}
+	assertEqual(t, spans[0].Name(), "data.leanix_test.read")
+	assertEqual(t, spans[0].SpanContext().TraceID().String(), "4bf92f3577b34da6a3ce929d0e0e4736")
+	assertEqual(t, spans[0].Parent().SpanID().String(), "00f067aa0ba902b7")
+}
+
+func TestConfigureTracingWithoutEndpoint(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	configureTracing()
+	if tracerProvider != nil {
+		t.Fatal("Expected no spans to be exported without an OTLP endpoint")
+	}
+}
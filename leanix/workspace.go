@@ -0,0 +1,93 @@
+package leanix
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Add the workspace attribute to a resource or data source and pass the client
+// of the selected workspace to its functions instead of the default client.
+func withWorkspace(resource *schema.Resource, isDataSource bool) *schema.Resource {
+	resource.Schema["workspace"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    !isDataSource,
+		Description: "The name of a workspace configured in the provider to use instead of the provider's url and auth_header.",
+	}
+
+	resource.Create = workspaceCrudFunc(resource.Create)
+	resource.Read = workspaceCrudFunc(resource.Read)
+	resource.Update = workspaceCrudFunc(resource.Update)
+	resource.Delete = workspaceCrudFunc(resource.Delete)
+	if resource.CustomizeDiff != nil {
+		customizeDiff := resource.CustomizeDiff
+		resource.CustomizeDiff = func(d *schema.ResourceDiff, meta interface{}) error {
+			client, err := meta.(*LeanixClient).workspaceClient(d.Get("workspace").(string))
+			if err != nil {
+				return err
+			}
+			return customizeDiff(d, client)
+		}
+	}
+	if resource.Importer != nil && resource.Importer.State != nil {
+		resource.Importer.State = workspaceImporter(resource.Importer.State)
+	}
+	return resource
+}
+
+func workspaceCrudFunc(crudFunc func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if crudFunc == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, err := meta.(*LeanixClient).workspaceClient(d.Get("workspace").(string))
+		if err != nil {
+			return err
+		}
+		return crudFunc(d, client)
+	}
+}
+
+// Resources in a workspace configured in the provider are imported with the
+// ID "<workspace>:<id>".
+func workspaceImporter(importer schema.StateFunc) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		leanixClient := meta.(*LeanixClient)
+		parts := strings.SplitN(d.Id(), ":", 2)
+		if len(parts) == 2 {
+			if _, ok := leanixClient.workspaces[parts[0]]; ok {
+				d.SetId(parts[1])
+				d.Set("workspace", parts[0])
+			}
+		}
+
+		client, err := leanixClient.workspaceClient(d.Get("workspace").(string))
+		if err != nil {
+			return nil, err
+		}
+		return importer(d, client)
+	}
+}
+
+// Get the client for a workspace configured in the provider. Each workspace has
+// its own client, so their tokens are cached separately. Without a name the
+// default client using the provider's url and auth_header is returned.
+func (leanix *LeanixClient) workspaceClient(name string) (*LeanixClient, error) {
+	if name == "" {
+		if leanix.authHeader == "" {
+			return nil, errors.New("No auth_header configured in the provider. Set it or select one of the provider's workspaces with the workspace attribute.")
+		}
+		return leanix, nil
+	}
+
+	client, ok := leanix.workspaces[name]
+	if !ok {
+		return nil, errors.New("Workspace '" + name + "' is not configured in the provider.")
+	}
+	if leanix.ctx != nil {
+		return client.withContext(leanix.ctx), nil
+	}
+	return client, nil
+}
@@ -0,0 +1,162 @@
+package leanix
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// Configure the provider without connecting to LeanIX.
+func configureTestProvider(t *testing.T, raw map[string]interface{}) (*LeanixClient, error) {
+	if _, ok := raw["skip_connectivity_check"]; !ok {
+		raw["skip_connectivity_check"] = true
+	}
+	provider := Provider().(*schema.Provider)
+	d := schema.TestResourceDataRaw(t, provider.Schema, raw)
+	client, err := configureProvider(d)
+	if err != nil {
+		return nil, err
+	}
+	return client.(*LeanixClient), nil
+}
+
+func TestConfigureWorkspaces(t *testing.T) {
+	client, err := configureTestProvider(t, map[string]interface{}{
+		"url":         "https://eu-svc.leanix.net",
+		"auth_header": "Basic cHJvZHVjdGlvbg==",
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+			map[string]interface{}{"name": "us", "url": "https://us-svc.leanix.net", "auth_header": "Basic dXM="},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaultClient, err := client.workspaceClient("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, defaultClient, client)
+
+	sandbox, err := client.workspaceClient("sandbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, sandbox.url, "https://eu-svc.leanix.net")
+	assertEqual(t, sandbox.authHeader, "Basic c2FuZGJveA==")
+
+	us, err := sandbox.workspaceClient("us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, us.url, "https://us-svc.leanix.net")
+	if us == sandbox || us == client {
+		t.Fatal("Expected each workspace to have its own client and token cache")
+	}
+
+	if _, err := client.workspaceClient("unknown"); err == nil {
+		t.Fatal("Expected an error for a workspace which is not configured")
+	}
+}
+
+func TestConfigureDuplicateWorkspace(t *testing.T) {
+	_, err := configureTestProvider(t, map[string]interface{}{
+		"auth_header": "Basic cHJvZHVjdGlvbg==",
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected an error for a workspace configured twice")
+	}
+}
+
+func TestWithWorkspace(t *testing.T) {
+	// the acceptance tests may have set a default auth header
+	t.Setenv("LEANIX_AUTH_HEADER", "")
+	var readWith interface{}
+	resource := withWorkspace(&schema.Resource{
+		Read: func(d *schema.ResourceData, meta interface{}) error {
+			readWith = meta
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{},
+	}, false)
+
+	client, err := configureTestProvider(t, map[string]interface{}{
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"workspace": "sandbox"})
+	if err := resource.Read(d, client); err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, readWith, client.workspaces["sandbox"])
+
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	if err := resource.Read(d, client); err == nil {
+		t.Fatal("Expected an error without auth_header and workspace")
+	}
+
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId("sandbox:28fe4aa2-6e46-41a1-a131-72afb3acf256")
+	imported, err := resource.Importer.State(d, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, imported[0].Id(), "28fe4aa2-6e46-41a1-a131-72afb3acf256")
+	assertEqual(t, imported[0].Get("workspace"), "sandbox")
+}
+
+func TestAllResourcesHaveWorkspace(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	for name, resource := range provider.ResourcesMap {
+		if resource.Schema["workspace"] == nil {
+			t.Errorf("Resource %s has no workspace attribute", name)
+		}
+	}
+	for name, dataSource := range provider.DataSourcesMap {
+		if offlineDataSources[name] {
+			if dataSource.Schema["workspace"] != nil {
+				t.Errorf("Offline data source %s has a workspace attribute", name)
+			}
+		} else if dataSource.Schema["workspace"] == nil {
+			t.Errorf("Data source %s has no workspace attribute", name)
+		}
+	}
+}
+
+func TestOfflineDataSourceWithoutAuthHeader(t *testing.T) {
+	t.Setenv("LEANIX_AUTH_HEADER", "")
+	client, err := configureTestProvider(t, map[string]interface{}{
+		"url": "https://eu-svc.leanix.net",
+		"workspace": []interface{}{
+			map[string]interface{}{"name": "sandbox", "auth_header": "Basic c2FuZGJveA=="},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataSource := Provider().(*schema.Provider).DataSourcesMap["leanix_ldif"]
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"connector_type": "ee",
+		"connector_id":   "Kub-Dev-001",
+		"content": []interface{}{
+			map[string]interface{}{"type": "Deployment", "id": "1", "data": map[string]interface{}{"app": "Mail"}},
+		},
+	})
+	if err := dataSource.Read(d, client); err != nil {
+		t.Fatalf("Reading the LDIF data source without auth_header returned an error: %s", err)
+	}
+}
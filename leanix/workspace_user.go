@@ -0,0 +1,22 @@
+package leanix
+
+type WorkspacePermission struct {
+	Id          *string       `json:"id,omitempty"`
+	WorkspaceId string        `json:"workspaceId"`
+	User        WorkspaceUser `json:"user"`
+	Role        string        `json:"role"`
+	Status      string        `json:"status,omitempty"`
+}
+
+type WorkspaceUser struct {
+	Id        string `json:"id,omitempty"`
+	Email     string `json:"email"`
+	FirstName string `json:"firstName,omitempty"`
+	LastName  string `json:"lastName,omitempty"`
+}
+
+type WorkspaceInvitation struct {
+	User       WorkspaceUser       `json:"user"`
+	Permission WorkspacePermission `json:"permission"`
+	Message    string              `json:"message,omitempty"`
+}